@@ -0,0 +1,219 @@
+package qy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+)
+
+// DefaultBatchSize is the batch size used by ExecInBatches when
+// BatchOptions.BatchSize is not set.
+const DefaultBatchSize = 1000
+
+// BatchOptions configures how ExecInBatches splits a DELETE or UPDATE query
+// into batches.
+type BatchOptions struct {
+	// BatchSize is the maximum number of rows affected by each batch. It
+	// defaults to DefaultBatchSize.
+	BatchSize int
+	// Key is the column used to identify the rows of each batch, usually the
+	// primary key. If Key is nil the physical row location (ctid) is used
+	// instead.
+	Key qx.Field
+	// Pause is how long to wait between batches, giving other transactions a
+	// chance to acquire the locks they need. With ExecInBatchesContext the
+	// wait is cut short if the context is cancelled.
+	Pause time.Duration
+	// Progress is called after every batch with the batch number (starting
+	// from 1), the number of rows affected by that batch and the total number
+	// of rows affected so far. Returning an error stops any further batches
+	// and is returned by ExecInBatches.
+	Progress func(batch int, affected, total int64) error
+}
+
+// batchTarget contains the parts of a DELETE or UPDATE query needed to select
+// the rows of a batch.
+type batchTarget struct {
+	table      qx.BaseTable
+	fromTable  qx.Table
+	joinGroups qx.JoinGroups
+	where      qx.VariadicPredicate
+}
+
+// batchQuery wraps a DELETE or UPDATE statement in a query that only affects
+// the rows of the next batch, and returns the number of rows affected i.e.
+//
+//	WITH qy_batch AS (
+//	    SELECT key FROM table ... WHERE ... LIMIT n FOR UPDATE OF table SKIP LOCKED
+//	), qy_batch_stmt AS (
+//	    DELETE FROM table ... WHERE ... AND key = ANY(ARRAY(SELECT key FROM qy_batch)) RETURNING 1
+//	)
+//	SELECT COUNT(*) FROM qy_batch_stmt
+//
+// The stmt function receives the predicate restricting the statement to the
+// batch and must return the statement with all of its CTEs removed, because
+// data-modifying statements in WITH are only allowed at the top level.
+func batchQuery(target batchTarget, ctes qx.CTEs, opts BatchOptions, stmt func(qx.Predicate) qx.Query) SelectQuery {
+	lockTarget := target.table.GetAlias()
+	if lockTarget == "" {
+		lockTarget = target.table.GetName()
+	}
+	key := opts.Key
+	if key == nil {
+		key = Fieldf(lockTarget + ".ctid")
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	selectQuery := Select(Fieldf("?", key).As("key")).From(target.table)
+	if target.fromTable != nil {
+		selectQuery = selectQuery.CrossJoin(target.fromTable)
+	}
+	selectQuery.JoinGroups = append(selectQuery.JoinGroups, target.joinGroups...)
+	selectQuery.WherePredicates = target.where
+	selectQuery = selectQuery.Limit(batchSize)
+	batch := qx.NewCTE("qy_batch", Queryf("? FOR UPDATE OF "+lockTarget+" SKIP LOCKED", selectQuery.NestThis()))
+	batchStmt := qx.NewCTE("qy_batch_stmt", stmt(Predicatef("? = ANY(ARRAY(SELECT key FROM ?))", key, batch)))
+	return Select(Fieldf("COUNT(*)")).From(batchStmt).With(append(ctes[:len(ctes):len(ctes)], batch, batchStmt)...)
+}
+
+// execInBatches runs the batch query repeatedly until a batch affects no rows,
+// and returns the total number of rows affected. Each batch is run by calling
+// exec with a mapper that records the number of rows affected.
+func execInBatches(ctx context.Context, q SelectQuery, opts BatchOptions, exec func(q SelectQuery) error) (total int64, err error) {
+	count := Fieldf("COUNT(*)")
+	var affected int64
+	q = q.Selectx(func(row Row) {
+		affected = row.Int64_(count)
+	}, nil)
+	for batch := 1; ; batch++ {
+		if batch > 1 && opts.Pause > 0 {
+			timer := time.NewTimer(opts.Pause)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return total, ctx.Err()
+			}
+		}
+		affected = 0
+		if err = exec(q); err != nil {
+			return total, err
+		}
+		if affected == 0 {
+			return total, nil
+		}
+		total += affected
+		if opts.Progress != nil {
+			if err = opts.Progress(batch, affected, total); err != nil {
+				return total, err
+			}
+		}
+	}
+}
+
+// execBatchContext runs a single batch query on db with the context.
+func execBatchContext(ctx context.Context, db qx.QueryerContext) func(q SelectQuery) error {
+	return func(q SelectQuery) error {
+		it := q.Iter(ctx, db)
+		for it.Next() {
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+		return it.Close()
+	}
+}
+
+// ExecInBatches runs the DeleteQuery repeatedly, deleting at most
+// opts.BatchSize rows each time, until a batch finds no more rows to delete.
+// Each batch is its own statement so that locks are only ever held on one
+// batch of rows at a time. Rows locked by other transactions are skipped: if
+// only locked rows are left, the batch deletes nothing and ExecInBatches
+// returns, leaving those rows in place. It returns the total number of rows
+// deleted. Any RETURNING fields are ignored.
+func (q DeleteQuery) ExecInBatches(db qx.Queryer, opts BatchOptions) (int64, error) {
+	if q.FromTable == nil {
+		return 0, errors.New("cannot run DELETE in batches without a table")
+	}
+	return execInBatches(context.Background(), q.batchQuery(opts), opts, func(q SelectQuery) error {
+		return q.Exec(db)
+	})
+}
+
+// ExecInBatchesContext is like ExecInBatches, but runs each batch with the
+// context and stops waiting between batches once it is cancelled.
+func (q DeleteQuery) ExecInBatchesContext(ctx context.Context, db qx.QueryerContext, opts BatchOptions) (int64, error) {
+	if q.FromTable == nil {
+		return 0, errors.New("cannot run DELETE in batches without a table")
+	}
+	return execInBatches(ctx, q.batchQuery(opts), opts, execBatchContext(ctx, db))
+}
+
+func (q DeleteQuery) batchQuery(opts BatchOptions) SelectQuery {
+	target := batchTarget{
+		table:      q.FromTable,
+		fromTable:  q.UsingTable,
+		joinGroups: q.JoinGroups,
+		where:      q.WherePredicates,
+	}
+	batch := batchQuery(target, q.CTEs, opts, func(predicate qx.Predicate) qx.Query {
+		q.CTEs = nil
+		predicates := q.WherePredicates.Predicates
+		q.WherePredicates.Predicates = append(predicates[:len(predicates):len(predicates)], predicate)
+		q.ReturningFields = qx.Fields{Fieldf("1")}
+		return q
+	})
+	batch.Log = q.Log
+	batch.ParamValues = q.ParamValues
+	return batch
+}
+
+// ExecInBatches runs the UpdateQuery repeatedly, updating at most
+// opts.BatchSize rows each time, until a batch finds no more rows to update.
+// Like DeleteQuery.ExecInBatches, rows locked by other transactions are
+// skipped and may be left as they are. It returns the total number of rows
+// updated. Any RETURNING fields are ignored.
+//
+// Since batches are run until no rows match, the WHERE clause must exclude
+// rows that have already been updated (e.g. SET status = 'archived' WHERE
+// status <> 'archived'), otherwise ExecInBatches will never finish.
+func (q UpdateQuery) ExecInBatches(db qx.Queryer, opts BatchOptions) (int64, error) {
+	if q.UpdateTable == nil {
+		return 0, errors.New("cannot run UPDATE in batches without a table")
+	}
+	return execInBatches(context.Background(), q.batchQuery(opts), opts, func(q SelectQuery) error {
+		return q.Exec(db)
+	})
+}
+
+// ExecInBatchesContext is like ExecInBatches, but runs each batch with the
+// context and stops waiting between batches once it is cancelled.
+func (q UpdateQuery) ExecInBatchesContext(ctx context.Context, db qx.QueryerContext, opts BatchOptions) (int64, error) {
+	if q.UpdateTable == nil {
+		return 0, errors.New("cannot run UPDATE in batches without a table")
+	}
+	return execInBatches(ctx, q.batchQuery(opts), opts, execBatchContext(ctx, db))
+}
+
+func (q UpdateQuery) batchQuery(opts BatchOptions) SelectQuery {
+	target := batchTarget{
+		table:      q.UpdateTable,
+		fromTable:  q.FromTable,
+		joinGroups: q.JoinGroups,
+		where:      q.WherePredicates,
+	}
+	batch := batchQuery(target, q.CTEs, opts, func(predicate qx.Predicate) qx.Query {
+		q.CTEs = nil
+		predicates := q.WherePredicates.Predicates
+		q.WherePredicates.Predicates = append(predicates[:len(predicates):len(predicates)], predicate)
+		q.ReturningFields = qx.Fields{Fieldf("1")}
+		return q
+	})
+	batch.Log = q.Log
+	batch.ParamValues = q.ParamValues
+	return batch
+}
@@ -0,0 +1,97 @@
+package qy
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/bokwoon95/qx-postgres/tables"
+	"github.com/matryer/is"
+)
+
+func TestBatchQuery(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           SelectQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "DELETE by ctid"
+			u := tables.USERS().As("u")
+			q := DeleteFrom(u).Where(u.DISPLAYNAME.EqString("aaa")).batchQuery(BatchOptions{})
+			wantQuery := "WITH qy_batch AS (" +
+				"SELECT u.ctid AS key FROM public.users AS u WHERE u.displayname = $1 LIMIT $2" +
+				" FOR UPDATE OF u SKIP LOCKED" +
+				"), qy_batch_stmt AS (" +
+				"DELETE FROM public.users AS u WHERE u.displayname = $3" +
+				" AND u.ctid = ANY(ARRAY(SELECT key FROM qy_batch)) RETURNING 1" +
+				") SELECT COUNT(*) FROM qy_batch_stmt"
+			wantArgs := []interface{}{"aaa", uint64(DefaultBatchSize), "aaa"}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "UPDATE by primary key"
+			u, ur := tables.USERS(), tables.USER_ROLES().As("ur")
+			q := Update(u).
+				Set(u.PASSWORD.SetString("")).
+				From(ur).
+				Where(ur.UID.Eq(u.UID), ur.ROLE.EqString("banned"), u.PASSWORD.NeString("")).
+				batchQuery(BatchOptions{BatchSize: 500, Key: u.UID})
+			wantQuery := "WITH qy_batch AS (" +
+				"SELECT users.uid AS key FROM public.users CROSS JOIN public.user_roles AS ur" +
+				" WHERE ur.uid = users.uid AND ur.role = $1 AND users.password <> $2 LIMIT $3" +
+				" FOR UPDATE OF users SKIP LOCKED" +
+				"), qy_batch_stmt AS (" +
+				"UPDATE public.users SET password = $4 FROM public.user_roles AS ur" +
+				" WHERE ur.uid = users.uid AND ur.role = $5 AND users.password <> $6" +
+				" AND users.uid = ANY(ARRAY(SELECT key FROM qy_batch)) RETURNING 1" +
+				") SELECT COUNT(*) FROM qy_batch_stmt"
+			wantArgs := []interface{}{"banned", "", uint64(500), "", "banned", ""}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestExecInBatchesContext(t *testing.T) {
+	is := is.New(t)
+	db, d := newFakeDB(t)
+	defer db.Close()
+	d.columns = []string{"count"}
+	counts := []int64{2, 1, 0}
+	d.queryRows = func(query string) [][]driver.Value {
+		count := counts[0]
+		counts = counts[1:]
+		return [][]driver.Value{{count}}
+	}
+	u := tables.USERS()
+	q := DeleteFrom(u).Where(u.DISPLAYNAME.EqString("aaa"))
+	total, err := q.ExecInBatchesContext(context.Background(), db, BatchOptions{})
+	is.NoErr(err)
+	is.Equal(int64(3), total)
+
+	// cancelling the context stops the pause between batches
+	counts = []int64{2, 1, 0}
+	ctx, cancel := context.WithCancel(context.Background())
+	total, err = q.ExecInBatchesContext(ctx, db, BatchOptions{
+		Pause: time.Hour,
+		Progress: func(batch int, affected, total int64) error {
+			cancel()
+			return nil
+		},
+	})
+	is.Equal(context.Canceled, err)
+	is.Equal(int64(2), total)
+}
@@ -249,6 +249,9 @@ import (
 {{template "table_struct_definition" $table}}
 {{template "table_constructor" $table}}
 {{template "table_as" $table}}
+{{- if eq $table.RawType "BASE TABLE"}}
+{{template "table_excluded" $table}}
+{{- end}}
 {{- end}}
 
 {{- define "table_struct_definition"}}
@@ -269,6 +272,12 @@ func {{$table.Constructor}}() {{$table.StructName}} {
 	{{- range $_, $field := $table.Fields}}
 	tbl.{{uppercase $field.Name}} = {{$field.Constructor}}("{{$field.Name}}", tbl.TableInfo)
 	{{- end}}
+	{{- /* replaces the fields appended by the field constructors, so that each field is listed once */}}
+	tbl.TableInfo.Fields = []qx.Field{
+		{{- range $_, $field := $table.Fields}}
+		tbl.{{uppercase $field.Name}},
+		{{- end}}
+	}
 	return tbl
 }
 {{- end}}
@@ -282,6 +291,14 @@ func (tbl {{$table.StructName}}) As(alias string) {{$table.StructName}} {
 	return tbl2
 }
 {{- end}}
+{{- end}}
+
+{{- define "table_excluded"}}
+{{- with $table := .}}
+func (tbl {{$table.StructName}}) Excluded() {{$table.StructName}} {
+	return tbl.As("EXCLUDED")
+}
+{{- end}}
 {{- end}}`
 
 // writeTablesToFile will write the tables into a file specified by
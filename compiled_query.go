@@ -0,0 +1,156 @@
+package qy
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+)
+
+// CompiledSelectQuery is a SelectQuery that has been rendered into its final
+// Postgres SQL once, so that executing it only costs binding the args and
+// scanning the rows. It is immutable and is created with SelectQuery.Compile.
+type CompiledSelectQuery struct {
+	query       string
+	args        []interface{}
+	params      []string
+	destTypes   []reflect.Type
+	mapper      func(Row)
+	accumulator func()
+	log         qx.Logger
+}
+
+// Compile renders the SelectQuery into a CompiledSelectQuery. The mapper is
+// called once to determine the select list and the types it scans into (the
+// scan layout). Values that should vary between executions must be
+// qx.Parameters, which are bound positionally by CompiledSelectQuery.Exec.
+func (q SelectQuery) Compile() (compiled CompiledSelectQuery, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
+	if q.Mapper == nil {
+		return compiled, errors.New("you can't call Compile without a mapper")
+	}
+	if err = q.Validate(); err != nil {
+		return compiled, err
+	}
+	r := &QyRow{QxRow: &qx.QxRow{}}
+	q.Mapper(r)
+	q.SelectFields = r.QxRow.Fields
+	if len(q.SelectFields) == 0 {
+		q.SelectFields = append(q.SelectFields, Fieldf("1"))
+	}
+	compiled.destTypes = make([]reflect.Type, len(r.QxRow.Dest))
+	for i, dest := range r.QxRow.Dest {
+		compiled.destTypes[i] = reflect.TypeOf(dest)
+	}
+	compiled.log, q.Log = q.Log, nil // logging is done on every Exec instead
+	compiled.query, compiled.args = q.ToSQL()
+	seen := make(map[string]bool)
+	for _, arg := range compiled.args {
+		if param, ok := arg.(qx.Parameter); ok && !seen[param.Name] {
+			seen[param.Name] = true
+			compiled.params = append(compiled.params, param.Name)
+		}
+	}
+	compiled.mapper = q.Mapper
+	compiled.accumulator = q.Accumulator
+	return compiled, nil
+}
+
+// SQL returns the compiled SQL query.
+func (c CompiledSelectQuery) SQL() string {
+	return c.query
+}
+
+// Params returns the names of the qx.Parameters in the compiled query, in the
+// order in which Exec binds its args to them.
+func (c CompiledSelectQuery) Params() []string {
+	params := make([]string, len(c.params))
+	copy(params, c.params)
+	return params
+}
+
+// Exec executes the compiled query with the args bound to its Params in
+// order, scanning the results into the mapper that it was compiled with.
+//
+// The mapper and accumulator usually write into variables they close over,
+// which every Exec shares. A CompiledSelectQuery must therefore not be
+// executed concurrently unless its mapper and accumulator are safe for
+// concurrent use.
+func (c CompiledSelectQuery) Exec(db qx.Queryer, args ...interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
+	if c.mapper == nil {
+		return errors.New("you can't call Exec on a query that was not compiled with SelectQuery.Compile")
+	}
+	if len(args) != len(c.params) {
+		return fmt.Errorf("query expects %d args %v, got %d", len(c.params), c.params, len(args))
+	}
+	values := make(map[string]interface{}, len(args))
+	for i, name := range c.params {
+		values[name] = args[i]
+	}
+	boundArgs, err := qx.BindParams(c.args, values)
+	if err != nil {
+		return err
+	}
+	if c.log != nil {
+		c.log.Println(qx.PostgresInterpolateSQL(c.query, boundArgs...))
+	}
+	// the mapper is called again to get fresh scan destinations for the rows
+	r := &QyRow{QxRow: &qx.QxRow{}}
+	c.mapper(r)
+	if len(r.QxRow.Dest) != len(c.destTypes) {
+		return errors.New("mapper does not match the scan layout it was compiled with")
+	}
+	for i, dest := range r.QxRow.Dest {
+		if reflect.TypeOf(dest) != c.destTypes[i] {
+			return errors.New("mapper does not match the scan layout it was compiled with")
+		}
+	}
+	r.QxRow.Active = true
+	rows, err := db.Query(c.query, boundArgs...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if len(c.destTypes) == 0 {
+		return nil
+	}
+	var rowcount int
+	for rows.Next() {
+		rowcount++
+		err = rows.Scan(r.QxRow.Dest...)
+		if err != nil {
+			return err
+		}
+		r.QxRow.Index = 0 // index must always be reset back to 0 before mapper is called
+		c.mapper(r)
+		if c.accumulator == nil {
+			break
+		}
+		c.accumulator()
+	}
+	if rowcount == 0 && c.accumulator == nil {
+		return sql.ErrNoRows
+	}
+	return rows.Err()
+}
@@ -0,0 +1,45 @@
+package qy
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+	"github.com/bokwoon95/qx-postgres/tables"
+	"github.com/matryer/is"
+)
+
+func TestSelectQuery_Compile(t *testing.T) {
+	is := is.New(t)
+	u := tables.USERS().As("u")
+	type User struct {
+		UID  int64
+		Name string
+	}
+	var user User
+	var users []User
+	q := From(u).
+		Where(u.UID.Eq(qx.NumberParam("uid")), u.DISPLAYNAME.Ne(qx.StringParam("name")), u.UID.Ne(qx.NumberParam("uid"))).
+		Selectx(func(row Row) {
+			user.UID = row.Int64(u.UID)
+			user.Name = row.String(u.DISPLAYNAME)
+		}, func() {
+			users = append(users, user)
+		})
+	compiled, err := q.Compile()
+	is.NoErr(err)
+	is.Equal("SELECT u.uid, u.displayname FROM public.users AS u WHERE u.uid = $1 AND u.displayname <> $2 AND u.uid <> $3", compiled.SQL())
+	is.Equal([]string{"uid", "name"}, compiled.Params())
+	compiled.Params()[0] = "modified"
+	is.Equal([]string{"uid", "name"}, compiled.Params()) // the compiled query cannot be modified
+
+	db, d := newFakeDB(t)
+	defer db.Close()
+	is.True(compiled.Exec(db, 1) != nil) // wrong number of args
+	is.NoErr(compiled.Exec(db, 1, "bob"))
+	is.Equal(0, len(users))                                         // the fake database never returns rows
+	is.Equal([]driver.Value{int64(1), "bob", int64(1)}, d.lastArgs) // uid is bound to both of its placeholders
+
+	_, err = From(u).Compile()
+	is.True(err != nil) // no mapper
+}
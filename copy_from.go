@@ -0,0 +1,140 @@
+package qy
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+	"github.com/lib/pq"
+)
+
+// CopyFromQuery bulk loads rows into a table with 'COPY table (columns) FROM
+// STDIN', which is much faster than a multi-row INSERT for large numbers of
+// rows. It is created with CopyFrom.
+type CopyFromQuery struct {
+	// COPY
+	IntoTable    qx.BaseTable
+	InsertFields qx.Fields
+	// Logging
+	Log qx.Logger
+}
+
+// CopyFrom returns a new CopyFromQuery that copies rows into the columns of
+// the table. Each row must have one value per field, in the same order.
+func CopyFrom(table qx.BaseTable, fields ...qx.Field) CopyFromQuery {
+	return CopyFromQuery{
+		IntoTable:    table,
+		InsertFields: fields,
+	}
+}
+
+// ToSQL returns the COPY statement as built by pq.CopyIn, or pq.CopyInSchema
+// if the table has a schema.
+func (q CopyFromQuery) ToSQL() (string, []interface{}) {
+	if q.IntoTable == nil {
+		return "", nil
+	}
+	columns := make([]string, len(q.InsertFields))
+	for i, field := range q.InsertFields {
+		columns[i] = field.GetName()
+	}
+	if t, ok := q.IntoTable.(interface{ GetSchema() string }); ok && t.GetSchema() != "" {
+		return pq.CopyInSchema(t.GetSchema(), q.IntoTable.GetName(), columns...), nil
+	}
+	return pq.CopyIn(q.IntoTable.GetName(), columns...), nil
+}
+
+// ExecRows copies the rows in the slice and returns the number of rows
+// copied.
+func (q CopyFromQuery) ExecRows(db qx.Queryer, rows [][]interface{}) (int64, error) {
+	var i int
+	return q.ExecFunc(db, func() ([]interface{}, error) {
+		if i >= len(rows) {
+			return nil, io.EOF
+		}
+		i++
+		return rows[i-1], nil
+	})
+}
+
+// ExecChan copies the rows received from the channel until it is closed, and
+// returns the number of rows copied.
+func (q CopyFromQuery) ExecChan(db qx.Queryer, rows <-chan []interface{}) (int64, error) {
+	return q.ExecFunc(db, func() ([]interface{}, error) {
+		row, ok := <-rows
+		if !ok {
+			return nil, io.EOF
+		}
+		return row, nil
+	})
+}
+
+// ExecFunc copies the rows returned by next until it returns io.EOF, and
+// returns the number of rows copied. Any other error from next aborts the
+// copy. Values are converted with qx.ConvertValue in the same way as
+// InsertQuery.InsertRow.
+//
+// COPY must run inside a transaction: if db is a *sql.DB a transaction is
+// started and committed or rolled back by ExecFunc, otherwise db must be a
+// *sql.Tx.
+func (q CopyFromQuery) ExecFunc(db qx.Queryer, next func() ([]interface{}, error)) (rowcount int64, err error) {
+	if q.IntoTable == nil || len(q.InsertFields) == 0 {
+		return 0, errors.New("COPY requires a table and at least one column")
+	}
+	if sqlDB, ok := db.(*sql.DB); ok {
+		tx, err := sqlDB.Begin()
+		if err != nil {
+			return 0, err
+		}
+		rowcount, err = q.ExecFunc(tx, next)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		return rowcount, tx.Commit()
+	}
+	preparer, ok := db.(interface {
+		Prepare(query string) (*sql.Stmt, error)
+	})
+	if !ok {
+		return 0, fmt.Errorf("COPY requires a *sql.DB or *sql.Tx, got %T", db)
+	}
+	query, _ := q.ToSQL()
+	if q.Log != nil {
+		q.Log.Println(query)
+	}
+	stmt, err := preparer.Prepare(query)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	for {
+		row, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return rowcount, err
+		}
+		if len(row) != len(q.InsertFields) {
+			return rowcount, fmt.Errorf("row %d has %d values, expected %d", rowcount, len(row), len(q.InsertFields))
+		}
+		values := make([]interface{}, len(row))
+		for i := range row {
+			if values[i], err = qx.ConvertValue(q.InsertFields[i], row[i]); err != nil {
+				return rowcount, fmt.Errorf("row %d: %s: %w", rowcount, q.InsertFields[i].GetName(), err)
+			}
+		}
+		if _, err = stmt.Exec(values...); err != nil {
+			return rowcount, err
+		}
+		rowcount++
+	}
+	// an Exec with no args flushes the buffered rows to the database
+	if _, err = stmt.Exec(); err != nil {
+		return rowcount, err
+	}
+	return rowcount, nil
+}
@@ -0,0 +1,44 @@
+package qy
+
+import (
+	"testing"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+	"github.com/bokwoon95/qx-postgres/tables"
+	"github.com/matryer/is"
+)
+
+func TestCopyFromQuery(t *testing.T) {
+	is := is.New(t)
+	u := tables.USERS()
+	q := CopyFrom(u, u.DISPLAYNAME, u.EMAIL)
+	wantQuery := `COPY "public"."users" ("displayname", "email") FROM STDIN`
+	gotQuery, _ := q.ToSQL()
+	is.Equal(wantQuery, gotQuery)
+
+	// a table without a schema is not qualified
+	tbl := qx.NewTableInfo("", "users")
+	gotQuery, _ = CopyFrom(tbl, qx.NewStringField("email", tbl)).ToSQL()
+	is.Equal(`COPY "users" ("email") FROM STDIN`, gotQuery)
+
+	db, d := newFakeDB(t)
+	defer db.Close()
+	rowcount, err := q.ExecRows(db, [][]interface{}{{"a", "a@x.com"}, {"b", "b@x.com"}})
+	is.NoErr(err)
+	is.Equal(int64(2), rowcount)
+	// two rows followed by the flush
+	is.Equal([]string{"BEGIN", wantQuery, wantQuery, wantQuery, "COMMIT"}, d.getLog())
+
+	rows := make(chan []interface{}, 3)
+	rows <- []interface{}{"a", "a@x.com"}
+	rows <- []interface{}{"b", "b@x.com"}
+	rows <- []interface{}{"c", "c@x.com"}
+	close(rows)
+	rowcount, err = q.ExecChan(db, rows)
+	is.NoErr(err)
+	is.Equal(int64(3), rowcount)
+
+	_, err = q.ExecRows(db, [][]interface{}{{"a"}})
+	is.True(err != nil) // wrong number of values
+	is.Equal("ROLLBACK", d.getLog()[len(d.getLog())-1])
+}
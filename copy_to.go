@@ -0,0 +1,120 @@
+package qy
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+	"github.com/lib/pq"
+)
+
+// Copier is an interface used to stream the output of a 'COPY ... TO STDOUT'
+// statement into a writer, returning the number of rows copied. lib/pq does
+// not support COPY TO, so it must be provided by a driver that does. The
+// pgcopier package provides one backed by pgconn.
+type Copier interface {
+	CopyTo(w io.Writer, query string) (rowcount int64, err error)
+}
+
+// CopyFormat is the format of the data written by COPY.
+type CopyFormat string
+
+// CopyFormats
+const (
+	CopyFormatCSV    CopyFormat = "csv"
+	CopyFormatText   CopyFormat = "text"
+	CopyFormatBinary CopyFormat = "binary"
+)
+
+// CopyToOptions configures CopyTo.
+type CopyToOptions struct {
+	// DB streams the COPY output. It must be backed by a driver other than
+	// lib/pq, see Copier.
+	DB Copier
+
+	// Format defaults to CopyFormatCSV.
+	Format CopyFormat
+
+	// Header writes the column names as the first line. Only valid for CSV.
+	Header bool
+
+	// Delimiter is the character that separates columns. Defaults to a comma
+	// for CSV and a tab for text.
+	Delimiter string
+
+	// Null is the string that represents a NULL value. Defaults to an empty
+	// string for CSV and \N for text.
+	Null string
+}
+
+// CopyToSQL renders the SelectQuery as 'COPY (query) TO STDOUT WITH
+// (options)'. COPY does not accept bind parameters, so the args of the
+// SelectQuery are quoted and inlined into the query with
+// qx.PostgresInlineArgs. If the SelectQuery has a mapper and no select
+// fields, the fields are taken from the mapper.
+func CopyToSQL(q SelectQuery, opts CopyToOptions) (string, error) {
+	if len(q.SelectFields) == 0 && q.Mapper != nil {
+		r := &QyRow{QxRow: &qx.QxRow{}}
+		q.Mapper(r)
+		q.SelectFields = r.QxRow.Fields
+	}
+	if len(q.SelectFields) == 0 {
+		return "", errors.New("COPY requires a query with at least one field")
+	}
+	if err := q.Validate(); err != nil {
+		return "", err
+	}
+	if opts.Format == "" {
+		opts.Format = CopyFormatCSV
+	}
+	switch opts.Format {
+	case CopyFormatCSV, CopyFormatText, CopyFormatBinary:
+	default:
+		return "", errors.New("unknown COPY format " + string(opts.Format))
+	}
+	if opts.Header && opts.Format != CopyFormatCSV {
+		return "", errors.New("HEADER is only supported for the csv format")
+	}
+	q.Nested = true // keep the ? placeholders for PostgresInlineArgs
+	query, args := q.ToSQL()
+	args, err := qx.BindParams(args, q.ParamValues)
+	if err != nil {
+		return "", err
+	}
+	if query, err = qx.PostgresInlineArgs(query, args...); err != nil {
+		return "", err
+	}
+	options := []string{"FORMAT " + string(opts.Format)}
+	if opts.Header {
+		options = append(options, "HEADER")
+	}
+	if opts.Delimiter != "" {
+		options = append(options, "DELIMITER "+pq.QuoteLiteral(opts.Delimiter))
+	}
+	if opts.Null != "" {
+		options = append(options, "NULL "+pq.QuoteLiteral(opts.Null))
+	}
+	return "COPY (" + query + ") TO STDOUT WITH (" + strings.Join(options, ", ") + ")", nil
+}
+
+// CopyTo streams the results of the SelectQuery into w in the format given
+// by opts, without scanning them into Go values. It returns the number of
+// rows copied. See CopyToSQL for how the query is rendered.
+//
+// CopyTo does not work with lib/pq, the driver used by the rest of qy, since
+// lib/pq cannot run COPY TO STDOUT. opts.DB must be a Copier backed by a
+// driver that can, such as a pgcopier.Copier.
+func CopyTo(w io.Writer, q SelectQuery, opts CopyToOptions) (int64, error) {
+	if opts.DB == nil {
+		return 0, errors.New("CopyTo requires a Copier")
+	}
+	query, err := CopyToSQL(q, opts)
+	if err != nil {
+		return 0, err
+	}
+	if q.Log != nil {
+		q.Log.Println(query)
+	}
+	return opts.DB.CopyTo(w, query)
+}
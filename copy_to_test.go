@@ -0,0 +1,46 @@
+package qy
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+	"github.com/bokwoon95/qx-postgres/tables"
+	"github.com/matryer/is"
+)
+
+// fakeCopier records the COPY query and writes a fixed CSV output.
+type fakeCopier struct {
+	query string
+}
+
+func (c *fakeCopier) CopyTo(w io.Writer, query string) (int64, error) {
+	c.query = query
+	_, err := io.WriteString(w, "uid,displayname\n1,bob\n")
+	return 1, err
+}
+
+func TestCopyTo(t *testing.T) {
+	is := is.New(t)
+	u := tables.USERS().As("u")
+	q := Select(u.UID, u.DISPLAYNAME).From(u).Where(u.DISPLAYNAME.Eq(qx.StringParam("name")), u.UID.GtInt(5))
+
+	gotQuery, err := CopyToSQL(q.Bind(map[string]interface{}{"name": "o'neil"}), CopyToOptions{Header: true, Null: "NULL"})
+	is.NoErr(err)
+	is.Equal("COPY (SELECT u.uid, u.displayname FROM public.users AS u WHERE u.displayname = 'o''neil' AND u.uid > 5)"+
+		" TO STDOUT WITH (FORMAT csv, HEADER, NULL 'NULL')", gotQuery)
+
+	_, err = CopyToSQL(q, CopyToOptions{})
+	is.True(err != nil) // name is not bound
+	_, err = CopyToSQL(q, CopyToOptions{Format: CopyFormatText, Header: true})
+	is.True(err != nil) // HEADER is only for csv
+
+	db := &fakeCopier{}
+	buf := &strings.Builder{}
+	rowcount, err := CopyTo(buf, q.Bind(map[string]interface{}{"name": "bob"}), CopyToOptions{DB: db, Format: CopyFormatText})
+	is.NoErr(err)
+	is.Equal(int64(1), rowcount)
+	is.Equal("uid,displayname\n1,bob\n", buf.String())
+	is.Equal("COPY (SELECT u.uid, u.displayname FROM public.users AS u WHERE u.displayname = 'bob' AND u.uid > 5) TO STDOUT WITH (FORMAT text)", db.query)
+}
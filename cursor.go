@@ -0,0 +1,190 @@
+package qy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+	"github.com/lib/pq"
+)
+
+// DefaultFetchSize is the number of rows fetched from a cursor at a time if
+// no fetch size is specified.
+const DefaultFetchSize = 1000
+
+// CursorDB is an interface used to run queries through a cursor. It is
+// implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type CursorDB interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// CursorOptions configures ExecCursor.
+type CursorOptions struct {
+	// Name is the name of the cursor. It is quoted as an identifier.
+	// Defaults to a random name.
+	Name string
+
+	// FetchSize is the number of rows fetched with each FETCH. Defaults to
+	// DefaultFetchSize.
+	FetchSize int
+
+	// WithHold declares the cursor WITH HOLD, so that it can outlive the
+	// transaction that created it.
+	WithHold bool
+
+	// Batch, if not nil, is called after each FETCH with the number of rows
+	// in the batch, once the mapper and accumulator have been called on every
+	// one of them. It can return ErrStopIteration to stop fetching without
+	// an error.
+	Batch func(rows int) error
+}
+
+// ExecCursor is like Exec, but reads the rows through a server-side cursor
+// i.e. 'DECLARE cursor CURSOR FOR query' followed by repeated 'FETCH n FROM
+// cursor' until the rows run out. Only opts.FetchSize rows are held in memory
+// at a time, which makes it suitable for result sets that are too big to be
+// read in one go. The cursor is always closed before ExecCursor returns,
+// including when an error occurs or ctx is cancelled.
+//
+// A cursor only exists on the connection that declared it, and (unless
+// opts.WithHold is set) only until the end of the transaction. If db is a
+// *sql.DB, ExecCursor runs inside its own read only transaction, or on its own
+// connection if opts.WithHold is set. If db is a *sql.Conn, ExecCursor runs
+// inside its own read only transaction on it unless opts.WithHold is set. A
+// *sql.Tx is used as is.
+func (q SelectQuery) ExecCursor(ctx context.Context, db CursorDB, opts CursorOptions) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
+	if q.Mapper == nil {
+		return errors.New("you can't call ExecCursor without a mapper")
+	}
+	if err = q.Validate(); err != nil {
+		return err
+	}
+	switch v := db.(type) {
+	case *sql.DB:
+		if opts.WithHold {
+			conn, err := v.Conn(ctx)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+			return q.ExecCursor(ctx, conn, opts)
+		}
+		return q.execCursorTx(ctx, v, opts)
+	case *sql.Conn:
+		if !opts.WithHold {
+			// DECLARE without WITH HOLD fails outside a transaction block
+			return q.execCursorTx(ctx, v, opts)
+		}
+	}
+	if opts.Name == "" {
+		opts.Name = "qy_cursor_" + strings.ToLower(qx.RandomString(8))
+	}
+	if opts.FetchSize <= 0 {
+		opts.FetchSize = DefaultFetchSize
+	}
+	r := &QyRow{QxRow: &qx.QxRow{}}
+	q.Mapper(r)
+	q.SelectFields = r.QxRow.Fields
+	if len(q.SelectFields) == 0 {
+		return errors.New("you can't call ExecCursor without selecting any fields")
+	}
+	r.QxRow.Active = true
+	query, args := q.ToSQL()
+	if args, err = qx.BindParams(args, q.ParamValues); err != nil {
+		return err
+	}
+	name := pq.QuoteIdentifier(opts.Name)
+	declare := "DECLARE " + name + " NO SCROLL CURSOR"
+	if opts.WithHold {
+		declare += " WITH HOLD"
+	}
+	if _, err = db.ExecContext(ctx, declare+" FOR "+query, args...); err != nil {
+		return err
+	}
+	defer func() {
+		// the cursor is closed with a fresh context so that it is still
+		// closed if ctx was cancelled. If the transaction was aborted the
+		// cursor is already gone, so the error is only reported if there
+		// isn't one already.
+		_, closeErr := db.ExecContext(context.Background(), "CLOSE "+name)
+		if err == nil {
+			err = closeErr
+		}
+	}()
+	fetch := "FETCH FORWARD " + strconv.Itoa(opts.FetchSize) + " FROM " + name
+	for {
+		var n int
+		n, err = q.fetchCursor(ctx, db, fetch, r)
+		if errors.Is(err, ErrStopIteration) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if opts.Batch != nil && n > 0 {
+			if err = opts.Batch(n); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+		}
+		if n < opts.FetchSize {
+			return nil
+		}
+	}
+}
+
+// execCursorTx runs ExecCursor inside a new read only transaction.
+func (q SelectQuery) execCursorTx(ctx context.Context, db TxBeginner, opts CursorOptions) error {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	if err = q.ExecCursor(ctx, tx, opts); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// fetchCursor runs one FETCH and passes its rows to the mapper and
+// accumulator of the SelectQuery. It returns the number of rows fetched.
+func (q SelectQuery) fetchCursor(ctx context.Context, db CursorDB, fetch string, r *QyRow) (n int, err error) {
+	rows, err := db.QueryContext(ctx, fetch)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		n++
+		if err = rows.Scan(r.QxRow.Dest...); err != nil {
+			return n, err
+		}
+		r.QxRow.Index = 0 // index must always be reset back to 0 before mapper is called
+		q.Mapper(r)
+		switch {
+		case q.AccumulatorErr != nil:
+			if err = q.AccumulatorErr(); err != nil {
+				return n, err
+			}
+		case q.Accumulator != nil:
+			q.Accumulator()
+		}
+	}
+	return n, rows.Err()
+}
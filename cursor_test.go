@@ -0,0 +1,113 @@
+package qy
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bokwoon95/qx-postgres/tables"
+	"github.com/matryer/is"
+)
+
+func TestSelectQuery_ExecCursor(t *testing.T) {
+	u := tables.USERS().As("u")
+	// newCursorDB returns a fake database holding five users, which are
+	// handed out by each FETCH
+	newCursorDB := func(t *testing.T) (*fakeDriver, *sql.DB) {
+		db, d := newFakeDB(t)
+		t.Cleanup(func() { db.Close() })
+		d.columns = []string{"uid"}
+		remaining := [][]driver.Value{{int64(1)}, {int64(2)}, {int64(3)}, {int64(4)}, {int64(5)}}
+		d.queryRows = func(query string) [][]driver.Value {
+			if !strings.HasPrefix(query, "FETCH FORWARD 2 FROM") {
+				return nil
+			}
+			n := 2
+			if n > len(remaining) {
+				n = len(remaining)
+			}
+			batch := remaining[:n]
+			remaining = remaining[n:]
+			return batch
+		}
+		return d, db
+	}
+
+	t.Run("all rows", func(t *testing.T) {
+		is := is.New(t)
+		d, db := newCursorDB(t)
+		var uid int64
+		var uids []int64
+		var batches []int
+		err := From(u).Selectx(func(row Row) {
+			uid = row.Int64(u.UID)
+		}, func() {
+			uids = append(uids, uid)
+		}).ExecCursor(context.Background(), db, CursorOptions{
+			Name:      "c",
+			FetchSize: 2,
+			Batch: func(rows int) error {
+				batches = append(batches, rows)
+				return nil
+			},
+		})
+		is.NoErr(err)
+		is.Equal([]int64{1, 2, 3, 4, 5}, uids)
+		is.Equal([]int{2, 2, 1}, batches)
+		is.Equal([]string{
+			"BEGIN READ ONLY",
+			"DECLARE \"c\" NO SCROLL CURSOR FOR SELECT u.uid FROM public.users AS u",
+			"FETCH FORWARD 2 FROM \"c\"",
+			"FETCH FORWARD 2 FROM \"c\"",
+			"FETCH FORWARD 2 FROM \"c\"",
+			"CLOSE \"c\"",
+			"COMMIT",
+		}, d.getLog())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		is := is.New(t)
+		d, db := newCursorDB(t)
+		var uid int64
+		errStop := errors.New("stop")
+		err := From(u).SelectxErr(func(row Row) {
+			uid = row.Int64(u.UID)
+		}, func() error {
+			if uid == 3 {
+				return errStop
+			}
+			return nil
+		}).ExecCursor(context.Background(), db, CursorOptions{Name: "c", FetchSize: 2, WithHold: true})
+		is.Equal(errStop, err)
+		is.Equal([]string{
+			"DECLARE \"c\" NO SCROLL CURSOR WITH HOLD FOR SELECT u.uid FROM public.users AS u",
+			"FETCH FORWARD 2 FROM \"c\"",
+			"FETCH FORWARD 2 FROM \"c\"",
+			"CLOSE \"c\"",
+		}, d.getLog())
+	})
+
+	t.Run("conn", func(t *testing.T) {
+		is := is.New(t)
+		d, db := newCursorDB(t)
+		conn, err := db.Conn(context.Background())
+		is.NoErr(err)
+		defer conn.Close()
+		// a cursor without WITH HOLD needs a transaction, and the name is
+		// quoted as an identifier
+		err = From(u).SelectRowx(func(row Row) {
+			row.Int64(u.UID)
+		}).ExecCursor(context.Background(), conn, CursorOptions{Name: `c"; DROP TABLE users; --`, FetchSize: 10})
+		is.NoErr(err)
+		is.Equal([]string{
+			"BEGIN READ ONLY",
+			`DECLARE "c""; DROP TABLE users; --" NO SCROLL CURSOR FOR SELECT u.uid FROM public.users AS u`,
+			`FETCH FORWARD 10 FROM "c""; DROP TABLE users; --"`,
+			`CLOSE "c""; DROP TABLE users; --"`,
+			"COMMIT",
+		}, d.getLog())
+	})
+}
@@ -22,8 +22,11 @@ type DeleteQuery struct {
 	WherePredicates qx.VariadicPredicate
 	// RETURNING
 	ReturningFields qx.Fields
+	AliasDuplicates bool
 	Mapper          func(Row)
 	Accumulator     func()
+	// Parameters
+	ParamValues interface{}
 	// Logging
 	Log qx.Logger
 }
@@ -67,10 +70,20 @@ func (q DeleteQuery) ToSQL() (string, []interface{}) {
 			}
 			if q.UsingTable.GetAlias() != "" {
 				buf.WriteString("USING " + usingQuery + " AS " + q.UsingTable.GetAlias())
+				if columnAliases := qx.ColumnAliasSQL(q.UsingTable); columnAliases != "" {
+					buf.WriteString(" " + columnAliases)
+				}
 			} else {
 				buf.WriteString("USING " + usingQuery)
 			}
 			args = append(args, usingArgs...)
+			if sample, ok := q.UsingTable.(qx.TableSample); ok {
+				sampleQuery, sampleArgs := sample.SampleSQL()
+				if sampleQuery != "" {
+					buf.WriteString(" " + sampleQuery)
+					args = append(args, sampleArgs...)
+				}
+			}
 		}
 	}
 	// JOIN
@@ -79,6 +92,9 @@ func (q DeleteQuery) ToSQL() (string, []interface{}) {
 	q.WherePredicates.Toplevel = true
 	q.WherePredicates.WriteSQL(buf, &args, "WHERE ", "", nil)
 	// RETURNING
+	if q.AliasDuplicates {
+		q.ReturningFields = qx.AliasDuplicateColumns(q.ReturningFields)
+	}
 	q.ReturningFields.WriteSQLWithAlias(buf, &args, "RETURNING ", "", nil)
 	query := buf.String()
 	if !q.Nested {
@@ -179,11 +195,24 @@ func (q DeleteQuery) CrossJoin(tbl qx.Table) DeleteQuery {
 	return q
 }
 
+func (q DeleteQuery) Where(predicates ...qx.Predicate) DeleteQuery {
+	q.WherePredicates.Predicates = append(q.WherePredicates.Predicates, predicates...)
+	return q
+}
+
 func (q DeleteQuery) Returning(fields ...qx.Field) DeleteQuery {
 	q.ReturningFields = append(q.ReturningFields, fields...)
 	return q
 }
 
+// AliasDuplicateColumns makes the DeleteQuery alias every table column in its
+// RETURNING list that shares its output name with another column as
+// 'tablealias_column'.
+func (q DeleteQuery) AliasDuplicateColumns() DeleteQuery {
+	q.AliasDuplicates = true
+	return q
+}
+
 func (q DeleteQuery) Returningx(mapper func(Row), accumulator func()) DeleteQuery {
 	q.Mapper = mapper
 	q.Accumulator = accumulator
@@ -195,7 +224,51 @@ func (q DeleteQuery) ReturningRowx(mapper func(Row)) DeleteQuery {
 	return q
 }
 
-func (q DeleteQuery) Exec(db qx.Queryer) (err error) {
+// Validate checks the DeleteQuery for mistakes that can be caught without
+// sending the query to the database. Exec calls Validate automatically.
+func (q DeleteQuery) Validate() error {
+	if err := validateArgs(q); err != nil {
+		return err
+	}
+	if q.Nested && !q.AliasDuplicates {
+		if err := qx.CheckDuplicateColumns(q.ReturningFields); err != nil {
+			return err
+		}
+	}
+	return validateNested(q.CTEs, append([]qx.Table{q.UsingTable}, joinTables(q.JoinGroups)...)...)
+}
+
+// Bind sets the values of the qx.Parameters in the DeleteQuery, keyed by their
+// names. They are bound into the args when the query is executed.
+func (q DeleteQuery) Bind(values map[string]interface{}) DeleteQuery {
+	q.ParamValues = values
+	return q
+}
+
+// BindStruct is like Bind, but takes the values from the fields of a struct
+// (or pointer to a struct). Fields are matched by their `db` tag or, failing
+// that, their name.
+func (q DeleteQuery) BindStruct(v interface{}) DeleteQuery {
+	q.ParamValues = v
+	return q
+}
+
+// Exec executes the DeleteQuery. If a mapper was set via Returningx or
+// ReturningRowx, the RETURNING rows are scanned into it. Use ExecRowsAffected
+// to also get the number of rows affected.
+func (q DeleteQuery) Exec(db qx.Queryer) error {
+	_, err := q.ExecRowsAffected(db)
+	return err
+}
+
+// ExecRowsAffected is like Exec, but also returns the number of rows affected
+// by the DeleteQuery. If there is a mapper, it is the number of RETURNING rows,
+// which Postgres returns one of for every affected row. Otherwise, if db
+// implements qx.Execer (as *sql.DB and *sql.Tx do), the query is executed
+// with Exec instead of Query and the count comes from its sql.Result. If db
+// only implements qx.Queryer and there is no mapper, the count cannot be
+// determined and -1 is returned.
+func (q DeleteQuery) ExecRowsAffected(db qx.Queryer) (rowsAffected int64, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			switch v := r.(type) {
@@ -206,6 +279,9 @@ func (q DeleteQuery) Exec(db qx.Queryer) (err error) {
 			}
 		}
 	}()
+	if err = q.Validate(); err != nil {
+		return 0, err
+	}
 	r := &QyRow{QxRow: &qx.QxRow{}}
 	if q.Mapper != nil {
 		q.Mapper(r) // call the mapper once on the *Row to get all the selected that the user is interested in
@@ -213,31 +289,51 @@ func (q DeleteQuery) Exec(db qx.Queryer) (err error) {
 	q.ReturningFields = r.QxRow.Fields // then, transfer the selected collected by *Row to the InsertQuery
 	r.QxRow.Active = true              // mark Row as active i.e.
 	query, args := q.ToSQL()
+	if args, err = qx.BindParams(args, q.ParamValues); err != nil {
+		return 0, err
+	}
+	if len(q.ReturningFields) == 0 {
+		// if user didn't specify any fields to return, don't bother scanning
+		// anything and report the number of affected rows instead
+		if execer, ok := db.(qx.Execer); ok {
+			result, err := execer.Exec(query, args...)
+			if err != nil {
+				return 0, err
+			}
+			return result.RowsAffected()
+		}
+		rows, err := db.Query(query, args...)
+		if err != nil {
+			return 0, err
+		}
+		return -1, rows.Close()
+	}
 	rows, err := db.Query(query, args...)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer rows.Close()
-	var rowcount int
-	if len(q.ReturningFields) == 0 {
-		// if user didn't specify any fields to return, don't bother scanning anything and return early
-		return nil
-	}
 	for rows.Next() {
-		rowcount++
+		rowsAffected++
+		if rowsAffected > 1 && q.Accumulator == nil {
+			// only the first row is mapped, the rest are just counted
+			continue
+		}
 		err = rows.Scan(r.QxRow.Dest...)
 		if err != nil {
-			return err
+			return rowsAffected, err
 		}
 		r.QxRow.Index = 0 // index must always be reset back to 0 before mapper is called
 		q.Mapper(r)
-		if q.Accumulator == nil {
-			break
+		if q.Accumulator != nil {
+			q.Accumulator()
 		}
-		q.Accumulator()
 	}
-	if rowcount == 0 && q.Accumulator == nil {
-		return sql.ErrNoRows
+	if err = rows.Err(); err != nil {
+		return rowsAffected, err
+	}
+	if rowsAffected == 0 && q.Accumulator == nil {
+		return 0, sql.ErrNoRows
 	}
-	return rows.Err()
+	return rowsAffected, nil
 }
@@ -0,0 +1,167 @@
+package qy
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+// fakeDriver is a database/sql driver that counts how many times each query
+// is prepared and records the args of the last query. Executing a query
+// listed in stale fails once with a "cached plan must not change result type"
+// error. Queries return the columns and rows of the driver, unless queryRows
+// is set. Executed statements report 1 row affected, unless execRows is set.
+// Every statement and transaction event is recorded in log.
+type fakeDriver struct {
+	mu        sync.Mutex
+	prepared  map[string]int
+	stale     map[string]bool
+	lastArgs  []driver.Value
+	columns   []string
+	rows      [][]driver.Value
+	queryRows func(query string) [][]driver.Value
+	execRows  func(query string) int64
+	log       []string
+}
+
+func (d *fakeDriver) getLog() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.log...)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{d}, nil }
+
+func (d *fakeDriver) prepareCount(query string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.prepared[query]
+}
+
+type fakeConn struct{ driver *fakeDriver }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	c.driver.prepared[query]++
+	return fakeStmt{driver: c.driver, query: query}, nil
+}
+
+func (c fakeConn) Close() error { return nil }
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	event := "BEGIN"
+	if opts.ReadOnly {
+		event += " READ ONLY"
+	}
+	c.driver.log = append(c.driver.log, event)
+	return fakeTx{c.driver}, nil
+}
+
+type fakeTx struct{ driver *fakeDriver }
+
+func (tx fakeTx) Commit() error   { return tx.end("COMMIT") }
+func (tx fakeTx) Rollback() error { return tx.end("ROLLBACK") }
+
+func (tx fakeTx) end(event string) error {
+	tx.driver.mu.Lock()
+	defer tx.driver.mu.Unlock()
+	tx.driver.log = append(tx.driver.log, event)
+	return nil
+}
+
+type fakeStmt struct {
+	driver *fakeDriver
+	query  string
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.driver.mu.Lock()
+	defer s.driver.mu.Unlock()
+	if s.driver.stale[s.query] {
+		delete(s.driver.stale, s.query)
+		return nil, errors.New("pq: cached plan must not change result type")
+	}
+	s.driver.log = append(s.driver.log, s.query)
+	if s.driver.execRows != nil {
+		return driver.RowsAffected(s.driver.execRows(s.query)), nil
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.driver.mu.Lock()
+	defer s.driver.mu.Unlock()
+	s.driver.lastArgs = args
+	s.driver.log = append(s.driver.log, s.query)
+	if s.driver.queryRows != nil {
+		return &fakeRows{columns: s.driver.columns, rows: s.driver.queryRows(s.query)}, nil
+	}
+	return &fakeRows{columns: s.driver.columns, rows: s.driver.rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+var registerFakeDriver sync.Once
+
+func newFakeDB(t *testing.T) (*sql.DB, *fakeDriver) {
+	d := &fakeDriver{prepared: make(map[string]int), stale: make(map[string]bool)}
+	registerFakeDriver.Do(func() {
+		sql.Register("qy_fake", fakeDrivers)
+	})
+	fakeDrivers.register(t.Name(), d)
+	db, err := sql.Open("qy_fake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, d
+}
+
+// fakeDriverRouter lets every test use its own fakeDriver, since a driver
+// name can only be registered once.
+type fakeDriverRouter struct {
+	mu      sync.Mutex
+	drivers map[string]*fakeDriver
+}
+
+var fakeDrivers = &fakeDriverRouter{drivers: make(map[string]*fakeDriver)}
+
+func (r *fakeDriverRouter) register(name string, d *fakeDriver) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.drivers[name] = d
+}
+
+func (r *fakeDriverRouter) Open(name string) (driver.Conn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.drivers[name].Open(name)
+}
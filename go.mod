@@ -6,6 +6,7 @@ require (
 	github.com/DATA-DOG/go-txdb v0.1.3
 	github.com/davecgh/go-spew v1.1.1
 	github.com/go-sql-driver/mysql v1.5.0 // indirect
+	github.com/jackc/pgconn v1.14.3
 	github.com/joho/godotenv v1.3.0
 	github.com/lib/pq v1.5.2
 	github.com/matryer/is v1.3.0
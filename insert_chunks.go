@@ -0,0 +1,128 @@
+package qy
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+)
+
+// MaxParams is the maximum number of placeholders Postgres accepts in a
+// single statement.
+const MaxParams = 65535
+
+// ChunkOptions configures InsertQuery.ExecInChunks.
+type ChunkOptions struct {
+	// ChunkSize is the maximum number of rows inserted by each statement. If
+	// it is zero, statements are only split when they would exceed
+	// MaxParams placeholders.
+	ChunkSize int
+}
+
+// ChunkError is returned by InsertQuery.ExecInChunks when one of the chunks
+// fails. Rows are numbered from 0 in the order they were added to the
+// InsertQuery.
+type ChunkError struct {
+	Chunk    int // the failed chunk, starting from 0
+	FirstRow int // the first row in the chunk
+	LastRow  int // the last row in the chunk
+	Err      error
+}
+
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("chunk %d (rows %d to %d): %s", e.Chunk, e.FirstRow, e.LastRow, e.Err)
+}
+
+// Unwrap returns the error that caused the chunk to fail.
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// ExecInChunks executes the InsertQuery as several statements, each inserting
+// a chunk of its VALUES rows, so that no statement exceeds MaxParams
+// placeholders (or opts.ChunkSize rows). All chunks are executed in one
+// transaction: if db is a *sql.DB a transaction is started and committed or
+// rolled back by ExecInChunks, if it is a *sql.Tx (or a StmtCache wrapping
+// one) it is used as is, and anything else is rejected. The mapper is called
+// on the RETURNING rows of every chunk in turn, so rows are accumulated in the
+// same order as they were inserted. It returns the total number of rows
+// inserted, and a *ChunkError if a chunk fails.
+func (q InsertQuery) ExecInChunks(db qx.Queryer, opts ChunkOptions) (rowsAffected int64, err error) {
+	if err = q.Validate(); err != nil {
+		return 0, err
+	}
+	switch v := db.(type) {
+	case *sql.DB:
+		tx, err := v.Begin()
+		if err != nil {
+			return 0, err
+		}
+		rowsAffected, err = q.ExecInChunks(tx, opts)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		return rowsAffected, tx.Commit()
+	case *sql.Tx:
+	case *StmtCache:
+		if !v.inTx {
+			return 0, errors.New("ExecInChunks requires a StmtCache that wraps a *sql.Tx")
+		}
+	default:
+		return 0, fmt.Errorf("ExecInChunks requires a *sql.DB or *sql.Tx, got %T", db)
+	}
+	chunks, err := q.chunks(opts)
+	if err != nil {
+		return 0, err
+	}
+	valuesList := q.ValuesList
+	var firstRow int
+	for i, chunkSize := range chunks {
+		q.ValuesList = valuesList[firstRow : firstRow+chunkSize]
+		n, err := q.ExecRowsAffected(db)
+		if err != nil {
+			return rowsAffected, &ChunkError{Chunk: i, FirstRow: firstRow, LastRow: firstRow + chunkSize - 1, Err: err}
+		}
+		rowsAffected += n
+		firstRow += chunkSize
+	}
+	return rowsAffected, nil
+}
+
+// chunks splits the VALUES rows of the InsertQuery into consecutive chunks
+// that fit under the parameter limit, and returns the number of rows in each.
+func (q InsertQuery) chunks(opts ChunkOptions) ([]int, error) {
+	if len(q.ValuesList) == 0 {
+		return []int{0}, nil
+	}
+	// count the placeholders used by everything other than the VALUES rows,
+	// including the RETURNING fields selected by the mapper
+	if q.Mapper != nil {
+		r := &QyRow{QxRow: &qx.QxRow{}}
+		q.Mapper(r)
+		q.ReturningFields = r.QxRow.Fields
+	}
+	valuesList := q.ValuesList
+	q.ValuesList = nil
+	q.Log = nil
+	_, baseArgs := q.ToSQL()
+	limit := MaxParams - len(baseArgs)
+	var chunks []int
+	var rows, params int
+	for i, row := range valuesList {
+		var rowArgs []interface{}
+		qx.ValuesList{row}.WriteSQL(&strings.Builder{}, &rowArgs, "", "")
+		if len(rowArgs) > limit {
+			return nil, fmt.Errorf("row %d needs %d placeholders, more than the %d available", i, len(rowArgs), limit)
+		}
+		if rows > 0 && (params+len(rowArgs) > limit || (opts.ChunkSize > 0 && rows == opts.ChunkSize)) {
+			chunks = append(chunks, rows)
+			rows, params = 0, 0
+		}
+		rows++
+		params += len(rowArgs)
+	}
+	return append(chunks, rows), nil
+}
@@ -0,0 +1,135 @@
+package qy
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bokwoon95/qx-postgres/tables"
+	"github.com/matryer/is"
+)
+
+func TestInsertQuery_chunks(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           InsertQuery
+		opts        ChunkOptions
+		wantChunks  []int
+	}
+	u := tables.USERS()
+	rows := func(n, columns int) InsertQuery {
+		q := InsertInto(u)
+		for i := 0; i < n; i++ {
+			row := make([]interface{}, columns)
+			for j := range row {
+				row[j] = j
+			}
+			q = q.Values(row...)
+		}
+		return q
+	}
+	tests := []TT{
+		{"no VALUES", InsertInto(u).DefaultValues(), ChunkOptions{}, []int{0}},
+		{"under the limit", rows(10, 3), ChunkOptions{}, []int{10}},
+		{"chunk size", rows(10, 3), ChunkOptions{ChunkSize: 4}, []int{4, 4, 2}},
+		{"over the limit", rows(30000, 3), ChunkOptions{}, []int{21845, 8155}},
+		{"over the limit with other args", rows(30000, 3).OnConflict(u.EMAIL).Where(u.UID.GtInt(1)).DoNothing(), ChunkOptions{}, []int{21844, 8156}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotChunks, err := tt.q.chunks(tt.opts)
+			is.NoErr(err)
+			is.Equal(tt.wantChunks, gotChunks)
+		})
+	}
+}
+
+func TestInsertQuery_ExecInChunks(t *testing.T) {
+	u := tables.USERS()
+	q := InsertInto(u).Columns(u.DISPLAYNAME).Values("a").Values("b").Values("c")
+
+	t.Run("rows affected", func(t *testing.T) {
+		is := is.New(t)
+		db, d := newFakeDB(t)
+		defer db.Close()
+		d.execRows = func(query string) int64 {
+			// one row is inserted for every row in VALUES
+			return int64(strings.Count(query, "(") - 1)
+		}
+		rowsAffected, err := q.ExecInChunks(db, ChunkOptions{ChunkSize: 2})
+		is.NoErr(err)
+		is.Equal(int64(3), rowsAffected)
+		is.Equal([]string{
+			"BEGIN",
+			"INSERT INTO public.users (displayname) VALUES ($1), ($2)",
+			"INSERT INTO public.users (displayname) VALUES ($1)",
+			"COMMIT",
+		}, d.getLog())
+	})
+
+	t.Run("RETURNING in order", func(t *testing.T) {
+		is := is.New(t)
+		db, d := newFakeDB(t)
+		defer db.Close()
+		d.columns = []string{"uid"}
+		var next int64
+		d.queryRows = func(query string) [][]driver.Value {
+			var rows [][]driver.Value
+			for i := 0; i < 2 && next < 3; i++ {
+				next++
+				rows = append(rows, []driver.Value{next})
+			}
+			return rows
+		}
+		var uid int64
+		var uids []int64
+		rowsAffected, err := q.Returningx(func(row Row) {
+			uid = row.Int64(u.UID)
+		}, func() {
+			uids = append(uids, uid)
+		}).ExecInChunks(db, ChunkOptions{ChunkSize: 2})
+		is.NoErr(err)
+		is.Equal(int64(3), rowsAffected)
+		is.Equal([]int64{1, 2, 3}, uids)
+	})
+
+	t.Run("failed chunk", func(t *testing.T) {
+		is := is.New(t)
+		db, d := newFakeDB(t)
+		defer db.Close()
+		d.stale["INSERT INTO public.users (displayname) VALUES ($1)"] = true // fails once
+		_, err := q.ExecInChunks(db, ChunkOptions{ChunkSize: 2})
+		var chunkErr *ChunkError
+		is.True(errors.As(err, &chunkErr))
+		is.Equal(1, chunkErr.Chunk)
+		is.Equal(2, chunkErr.FirstRow)
+		is.Equal(2, chunkErr.LastRow)
+		is.Equal("ROLLBACK", d.getLog()[len(d.getLog())-1])
+	})
+
+	t.Run("not a transaction", func(t *testing.T) {
+		is := is.New(t)
+		db, d := newFakeDB(t)
+		defer db.Close()
+		_, err := q.ExecInChunks(NewStmtCache(db, StmtCacheOptions{}), ChunkOptions{ChunkSize: 2})
+		is.True(err != nil)
+		is.Equal(0, len(d.getLog()))
+
+		// a StmtCache wrapping a *sql.Tx is already a transaction
+		tx, err := db.Begin()
+		is.NoErr(err)
+		_, err = q.ExecInChunks(NewStmtCache(tx, StmtCacheOptions{}), ChunkOptions{ChunkSize: 2})
+		is.NoErr(err)
+		is.NoErr(tx.Commit())
+		is.Equal([]string{
+			"BEGIN",
+			"INSERT INTO public.users (displayname) VALUES ($1), ($2)",
+			"INSERT INTO public.users (displayname) VALUES ($1)",
+			"COMMIT",
+		}, d.getLog())
+	})
+}
@@ -3,6 +3,7 @@ package qy
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/bokwoon95/qx-postgres/qx"
@@ -16,8 +17,11 @@ type InsertQuery struct {
 	// INSERT INTO
 	IntoTable    qx.BaseTable
 	InsertFields qx.Fields
+	// OVERRIDING
+	Overriding string
 	// VALUES
-	ValuesList qx.ValuesList
+	ValuesList       qx.ValuesList
+	UseDefaultValues bool
 	// SELECT
 	SelectQuery *SelectQuery
 	// ON CONFLICT
@@ -29,8 +33,11 @@ type InsertQuery struct {
 	ResolutionPredicates qx.VariadicPredicate
 	// RETURNING
 	ReturningFields qx.Fields
+	AliasDuplicates bool
 	Mapper          func(Row)
 	Accumulator     func()
+	// Parameters
+	ParamValues interface{}
 	// Logging
 	Log qx.Logger
 }
@@ -64,8 +71,20 @@ func (q InsertQuery) ToSQL() (string, []interface{}) {
 			q.InsertFields.WriteSQL(buf, &args, "(", ")", excludeTableQualifiers)
 		}
 	}
+	// OVERRIDING
+	if q.Overriding != "" {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("OVERRIDING " + q.Overriding)
+	}
 	// VALUES/SELECT
 	switch {
+	case q.UseDefaultValues:
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("DEFAULT VALUES")
 	case len(q.ValuesList) > 0:
 		q.ValuesList.WriteSQL(buf, &args, "VALUES ",  "")
 	case q.SelectQuery != nil:
@@ -114,6 +133,9 @@ func (q InsertQuery) ToSQL() (string, []interface{}) {
 		buf.WriteString("DO NOTHING")
 	}
 	// RETURNING
+	if q.AliasDuplicates {
+		q.ReturningFields = qx.AliasDuplicateColumns(q.ReturningFields)
+	}
 	q.ReturningFields.WriteSQLWithAlias(buf, &args, "RETURNING ", "", nil)
 	query := buf.String()
 	if !q.Nested {
@@ -153,11 +175,46 @@ func (q InsertQuery) Values(values ...interface{}) InsertQuery {
 	return q
 }
 
+// DefaultValues makes the InsertQuery insert a single row consisting of only
+// column defaults i.e. 'INSERT INTO table DEFAULT VALUES'.
+func (q InsertQuery) DefaultValues() InsertQuery {
+	q.UseDefaultValues = true
+	return q
+}
+
+// OverridingSystemValue lets explicit values take precedence over the values
+// generated by a GENERATED ALWAYS AS IDENTITY column.
+func (q InsertQuery) OverridingSystemValue() InsertQuery {
+	q.Overriding = "SYSTEM VALUE"
+	return q
+}
+
+// OverridingUserValue makes the database ignore explicit values for a
+// GENERATED BY DEFAULT AS IDENTITY column and use the generated value instead.
+func (q InsertQuery) OverridingUserValue() InsertQuery {
+	q.Overriding = "USER VALUE"
+	return q
+}
+
+// InsertRow adds a row of values to the InsertQuery, with each value
+// associated to its column. The first call to InsertRow also sets the
+// columns of the InsertQuery. Values are converted with qx.ConvertValue, so
+// slices are inserted as arrays and structs or maps as JSON. If a value cannot
+// be converted, the error is returned when the query is validated or
+// executed.
 func (q InsertQuery) InsertRow(sets ...qx.FieldValueSet) InsertQuery {
 	fields, values := make([]qx.Field, len(sets)), make([]interface{}, len(sets))
 	for i := range sets {
 		fields[i] = sets[i].Field
-		values[i] = sets[i].Value
+		value, err := qx.ConvertValue(sets[i].Field, sets[i].Value)
+		if err != nil {
+			var name string
+			if sets[i].Field != nil {
+				name = sets[i].Field.GetName()
+			}
+			value = qx.ConvertError{Name: name, Err: err}
+		}
+		values[i] = value
 	}
 	if len(q.InsertFields) == 0 {
 		q.InsertFields = fields
@@ -205,6 +262,19 @@ func (c insertConflict) DoUpdateSet(sets ...qx.FieldValueSet) InsertQuery {
 	return *c.insertQuery
 }
 
+// DoUpdateSetExcluded is a shortcut for DoUpdateSet that sets each field to
+// the value proposed for insertion i.e. 'SET field = EXCLUDED.field'.
+func (c insertConflict) DoUpdateSetExcluded(fields ...qx.Field) InsertQuery {
+	sets := make([]qx.FieldValueSet, 0, len(fields))
+	for _, field := range fields {
+		if field == nil {
+			continue
+		}
+		sets = append(sets, qx.FieldValueSet{Field: field, Value: Excluded(field)})
+	}
+	return c.DoUpdateSet(sets...)
+}
+
 func Excluded(field qx.Field) qx.CustomField {
 	return qx.CustomField{Format: "EXCLUDED." + field.GetName()}
 }
@@ -219,6 +289,14 @@ func (q InsertQuery) Returning(fields ...qx.Field) InsertQuery {
 	return q
 }
 
+// AliasDuplicateColumns makes the InsertQuery alias every table column in its
+// RETURNING list that shares its output name with another column as
+// 'tablealias_column'.
+func (q InsertQuery) AliasDuplicateColumns() InsertQuery {
+	q.AliasDuplicates = true
+	return q
+}
+
 func (q InsertQuery) Returningx(mapper func(Row), accumulator func()) InsertQuery {
 	q.Mapper = mapper
 	q.Accumulator = accumulator
@@ -230,7 +308,94 @@ func (q InsertQuery) ReturningRowx(mapper func(Row)) InsertQuery {
 	return q
 }
 
-func (q InsertQuery) Exec(db qx.Queryer) (err error) {
+// Validate checks the InsertQuery for mistakes that can be caught without
+// sending the query to the database. Exec calls Validate automatically.
+func (q InsertQuery) Validate() error {
+	if err := validateArgs(q); err != nil {
+		return err
+	}
+	if q.UseDefaultValues && (len(q.InsertFields) > 0 || len(q.ValuesList) > 0 || q.SelectQuery != nil) {
+		return errors.New("DEFAULT VALUES cannot be combined with columns, VALUES or SELECT")
+	}
+	if len(q.Resolution) > 0 && q.ConflictConstraint == "" && len(q.ConflictFields) == 0 {
+		return errors.New("ON CONFLICT DO UPDATE requires either conflict target fields or a constraint name")
+	}
+	if q.Nested && !q.AliasDuplicates {
+		if err := qx.CheckDuplicateColumns(q.ReturningFields); err != nil {
+			return err
+		}
+	}
+	if err := validateNested(q.CTEs); err != nil {
+		return err
+	}
+	if q.SelectQuery != nil {
+		// the SELECT only supplies values by position, so its own output
+		// names don't matter
+		if err := q.SelectQuery.Validate(); err != nil {
+			return err
+		}
+	}
+	if q.IntoTable == nil {
+		return nil
+	}
+	qualifier := q.IntoTable.GetAlias()
+	if qualifier == "" {
+		qualifier = q.IntoTable.GetName()
+	}
+	// TableInfo.Fields is only a list of known columns, it may be empty for
+	// tables that are not generated
+	columns := make(map[string]bool)
+	for _, field := range q.IntoTable.GetFields() {
+		columns[field.GetName()] = true
+	}
+	for _, field := range q.ConflictFields {
+		switch field.(type) {
+		case qx.NumberField, qx.StringField, qx.TimeField, qx.BooleanField, qx.JSONField:
+			// only table columns can be checked, arbitrary index expressions
+			// (e.g. lower(email)) are left to the database
+			name := field.GetName()
+			if column, _ := field.ToSQL(nil); column != name && column != qualifier+"."+name {
+				return fmt.Errorf("conflict target %s is not a column of %s", column, q.IntoTable.GetName())
+			}
+			if len(columns) > 0 && !columns[name] {
+				return fmt.Errorf("conflict target %s is not a column of %s", name, q.IntoTable.GetName())
+			}
+		}
+	}
+	return nil
+}
+
+// Bind sets the values of the qx.Parameters in the InsertQuery, keyed by their
+// names. They are bound into the args when the query is executed.
+func (q InsertQuery) Bind(values map[string]interface{}) InsertQuery {
+	q.ParamValues = values
+	return q
+}
+
+// BindStruct is like Bind, but takes the values from the fields of a struct
+// (or pointer to a struct). Fields are matched by their `db` tag or, failing
+// that, their name.
+func (q InsertQuery) BindStruct(v interface{}) InsertQuery {
+	q.ParamValues = v
+	return q
+}
+
+// Exec executes the InsertQuery. If a mapper was set via Returningx or
+// ReturningRowx, the RETURNING rows are scanned into it. Use ExecRowsAffected
+// to also get the number of rows affected.
+func (q InsertQuery) Exec(db qx.Queryer) error {
+	_, err := q.ExecRowsAffected(db)
+	return err
+}
+
+// ExecRowsAffected is like Exec, but also returns the number of rows affected
+// by the InsertQuery. If there is a mapper, it is the number of RETURNING rows,
+// which Postgres returns one of for every affected row. Otherwise, if db
+// implements qx.Execer (as *sql.DB and *sql.Tx do), the query is executed
+// with Exec instead of Query and the count comes from its sql.Result. If db
+// only implements qx.Queryer and there is no mapper, the count cannot be
+// determined and -1 is returned.
+func (q InsertQuery) ExecRowsAffected(db qx.Queryer) (rowsAffected int64, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			switch v := r.(type) {
@@ -241,6 +406,9 @@ func (q InsertQuery) Exec(db qx.Queryer) (err error) {
 			}
 		}
 	}()
+	if err = q.Validate(); err != nil {
+		return 0, err
+	}
 	r := &QyRow{QxRow: &qx.QxRow{}}
 	if q.Mapper != nil {
 		q.Mapper(r) // call the mapper once on the *Row to get all the selected that the user is interested in
@@ -248,33 +416,53 @@ func (q InsertQuery) Exec(db qx.Queryer) (err error) {
 	q.ReturningFields = r.QxRow.Fields // then, transfer the selected collected by *Row to the InsertQuery
 	r.QxRow.Active = true              // mark Row as active i.e.
 	query, args := q.ToSQL()
+	if args, err = qx.BindParams(args, q.ParamValues); err != nil {
+		return 0, err
+	}
+	if len(q.ReturningFields) == 0 {
+		// if user didn't specify any fields to return, don't bother scanning
+		// anything and report the number of affected rows instead
+		if execer, ok := db.(qx.Execer); ok {
+			result, err := execer.Exec(query, args...)
+			if err != nil {
+				return 0, err
+			}
+			return result.RowsAffected()
+		}
+		rows, err := db.Query(query, args...)
+		if err != nil {
+			return 0, err
+		}
+		return -1, rows.Close()
+	}
 	rows, err := db.Query(query, args...)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer rows.Close()
-	var rowcount int
-	if len(q.ReturningFields) == 0 {
-		// if user didn't specify any fields to return, don't bother scanning anything and return early
-		return nil
-	}
 	for rows.Next() {
-		rowcount++
+		rowsAffected++
+		if rowsAffected > 1 && q.Accumulator == nil {
+			// only the first row is mapped, the rest are just counted
+			continue
+		}
 		err = rows.Scan(r.QxRow.Dest...)
 		if err != nil {
-			return err
+			return rowsAffected, err
 		}
 		r.QxRow.Index = 0 // index must always be reset back to 0 before mapper is called
 		q.Mapper(r)
-		if q.Accumulator == nil {
-			break
+		if q.Accumulator != nil {
+			q.Accumulator()
 		}
-		q.Accumulator()
 	}
-	if rowcount == 0 && q.Accumulator == nil {
-		return sql.ErrNoRows
+	if err = rows.Err(); err != nil {
+		return rowsAffected, err
+	}
+	if rowsAffected == 0 && q.Accumulator == nil {
+		return 0, sql.ErrNoRows
 	}
-	return rows.Err()
+	return rowsAffected, nil
 }
 
 func (q InsertQuery) As(alias string) InsertQuery {
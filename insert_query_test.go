@@ -2,6 +2,7 @@ package qy
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"math"
@@ -276,3 +277,123 @@ func TestInsertNull(t *testing.T) {
 	}).Exec(db)
 	fmt.Println(users)
 }
+
+func TestInsertQuery_Excluded(t *testing.T) {
+	is := is.New(t)
+	u := tables.USERS().As("u")
+	excluded := u.Excluded()
+	q := InsertInto(u).
+		InsertRow(
+			u.DISPLAYNAME.SetString("aaa"),
+			u.EMAIL.SetString("aaa@email.com"),
+			u.PASSWORD.SetString("yohoho"),
+		).
+		OnConflict(u.EMAIL).
+		DoUpdateSetExcluded(u.DISPLAYNAME, u.PASSWORD).
+		Where(
+			u.DISPLAYNAME.IsDistinctFrom(excluded.DISPLAYNAME),
+			excluded.PASSWORD.IsNotNull(),
+		)
+	wantQuery := "INSERT INTO public.users AS u (displayname, email, password)" +
+		" VALUES ($1, $2, $3)" +
+		" ON CONFLICT (email) DO UPDATE SET displayname = EXCLUDED.displayname, password = EXCLUDED.password" +
+		" WHERE u.displayname IS DISTINCT FROM EXCLUDED.displayname AND EXCLUDED.password IS NOT NULL"
+	gotQuery, gotArgs := q.ToSQL()
+	is.Equal(wantQuery, gotQuery)
+	is.Equal([]interface{}{"aaa", "aaa@email.com", "yohoho"}, gotArgs)
+	is.NoErr(q.Validate())
+
+	// typed EXCLUDED fields can be used as values in DoUpdateSet
+	gotQuery, _ = InsertInto(u).Values("aaa").OnConflict(u.EMAIL).
+		DoUpdateSet(u.DISPLAYNAME.Set(excluded.DISPLAYNAME)).ToSQL()
+	is.Equal("INSERT INTO public.users AS u VALUES ($1) ON CONFLICT (email)"+
+		" DO UPDATE SET displayname = EXCLUDED.displayname", gotQuery)
+}
+
+func TestInsertQuery_Validate(t *testing.T) {
+	is := is.New(t)
+	u, ur := tables.USERS().As("u"), tables.USER_ROLES().As("ur")
+	q := InsertInto(u).Values("aaa")
+	is.NoErr(q.OnConflict().DoNothing().Validate())
+	is.NoErr(q.OnConflictOnConstraint("users_email_key").DoUpdateSetExcluded(u.DISPLAYNAME).Validate())
+	is.True(q.OnConflict().DoUpdateSetExcluded(u.DISPLAYNAME).Validate() != nil) // DO UPDATE without conflict target
+	is.True(q.OnConflict(ur.ROLE).DoNothing().Validate() != nil)                 // conflict target not in table
+	is.True(q.OnConflict(ur.UID).DoNothing().Validate() != nil)                  // same name, different table
+	is.True(q.OnConflict(tables.USERS().As("u2").EMAIL).DoNothing().Validate() != nil)
+	is.NoErr(q.OnConflict(u.EMAIL).DoNothing().Validate())
+
+	// hand-written tables may not list their fields in TableInfo.Fields
+	tbl := &qx.TableInfo{Schema: "public", Name: "users"}
+	email := qx.NewStringField("email", tbl)
+	tbl.Fields = nil
+	is.NoErr(InsertInto(tbl).Values("aaa").OnConflict(email).DoNothing().Validate())
+	is.True(InsertInto(tbl).Values("aaa").OnConflict(ur.UID).DoNothing().Validate() != nil)
+	is.NoErr(q.OnConflict(Fieldf("lower(?)", u.EMAIL)).DoNothing().Validate()) // index expressions are not checked
+
+	// a value that cannot be converted is reported by Validate and Exec
+	bad := InsertInto(u).InsertRow(u.DISPLAYNAME.Set(map[string]interface{}{"c": make(chan int)}))
+	var convertErr qx.ConvertError
+	is.True(errors.As(bad.Validate(), &convertErr))
+	is.Equal("displayname", convertErr.Name)
+	db, _ := newFakeDB(t)
+	defer db.Close()
+	is.True(errors.As(bad.Exec(db), &convertErr))
+}
+
+func TestInsertQuery_Default(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           InsertQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u := tables.USERS().As("u")
+	tests := []TT{
+		{
+			"DEFAULT in VALUES",
+			InsertInto(u).Columns(u.UID, u.DISPLAYNAME, u.EMAIL).
+				Values(qx.Default, "aaa", "aaa@email.com").
+				Values(qx.Default, "bbb", qx.Default),
+			"INSERT INTO public.users AS u (uid, displayname, email)" +
+				" VALUES (DEFAULT, $1, $2), (DEFAULT, $3, DEFAULT)",
+			[]interface{}{"aaa", "aaa@email.com", "bbb"},
+		},
+		{
+			"DEFAULT in InsertRow",
+			InsertInto(u).InsertRow(u.UID.Set(qx.Default), u.EMAIL.SetString("aaa@email.com")),
+			"INSERT INTO public.users AS u (uid, email) VALUES (DEFAULT, $1)",
+			[]interface{}{"aaa@email.com"},
+		},
+		{
+			"DEFAULT VALUES",
+			InsertInto(u).DefaultValues().Returning(u.UID),
+			"INSERT INTO public.users AS u DEFAULT VALUES RETURNING u.uid",
+			nil,
+		},
+		{
+			"OVERRIDING SYSTEM VALUE",
+			InsertInto(u).Columns(u.UID, u.EMAIL).OverridingSystemValue().Values(1, "aaa@email.com"),
+			"INSERT INTO public.users AS u (uid, email) OVERRIDING SYSTEM VALUE VALUES ($1, $2)",
+			[]interface{}{1, "aaa@email.com"},
+		},
+		{
+			"OVERRIDING USER VALUE",
+			InsertInto(u).Columns(u.UID, u.EMAIL).OverridingUserValue().Values(1, "aaa@email.com"),
+			"INSERT INTO public.users AS u (uid, email) OVERRIDING USER VALUE VALUES ($1, $2)",
+			[]interface{}{1, "aaa@email.com"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+			is.NoErr(tt.q.Validate())
+		})
+	}
+	is := is.New(t)
+	is.True(InsertInto(u).Values(1).DefaultValues().Validate() != nil)
+}
@@ -0,0 +1,155 @@
+package qy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+)
+
+// ErrStopIteration can be returned by an accumulator passed to SelectxErr to
+// stop reading rows without Exec returning an error. It is recognised even if
+// it is wrapped.
+var ErrStopIteration = errors.New("stop iteration")
+
+// Iterator reads the rows of a SelectQuery one at a time. It is created with
+// SelectQuery.Iter and used like *sql.Rows:
+//
+//	it := q.Iter(ctx, db)
+//	defer it.Close()
+//	for it.Next() {
+//	    users = append(users, user) // user was populated by the mapper
+//	}
+//	if err := it.Err(); err != nil {
+//	    return err
+//	}
+type Iterator struct {
+	rows   *sql.Rows
+	row    *QyRow
+	mapper func(Row)
+	err    error
+}
+
+// Iter executes the SelectQuery and returns an Iterator over its rows. The
+// mapper set by Selectx or SelectRowx is called on every row as Next advances
+// to it, while the accumulator is ignored. Any error is reported by Err once
+// Next returns false.
+func (q SelectQuery) Iter(ctx context.Context, db qx.QueryerContext) *Iterator {
+	it := &Iterator{mapper: q.Mapper}
+	defer func() {
+		if r := recover(); r != nil {
+			it.fail(r)
+		}
+	}()
+	if q.Mapper == nil {
+		it.err = errors.New("you can't call Iter without a mapper")
+		return it
+	}
+	if it.err = q.Validate(); it.err != nil {
+		return it
+	}
+	it.row = &QyRow{QxRow: &qx.QxRow{}}
+	q.Mapper(it.row)
+	q.SelectFields = it.row.QxRow.Fields
+	if len(q.SelectFields) == 0 {
+		it.err = errors.New("you can't call Iter without selecting any fields")
+		return it
+	}
+	it.row.QxRow.Active = true
+	query, args := q.ToSQL()
+	if args, it.err = qx.BindParams(args, q.ParamValues); it.err != nil {
+		return it
+	}
+	it.rows, it.err = db.QueryContext(ctx, query, args...)
+	return it
+}
+
+// Next advances the Iterator to the next row and calls the mapper on it. It
+// returns false when there are no more rows or an error occurred, after which
+// the Iterator is closed.
+func (it *Iterator) Next() (ok bool) {
+	if it.err != nil || it.rows == nil {
+		return false
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			it.fail(r)
+			ok = false
+		}
+	}()
+	if !it.rows.Next() {
+		it.err = it.rows.Err()
+		it.Close()
+		return false
+	}
+	if it.err = it.rows.Scan(it.row.QxRow.Dest...); it.err != nil {
+		it.Close()
+		return false
+	}
+	it.row.QxRow.Index = 0 // index must always be reset back to 0 before mapper is called
+	it.mapper(it.row)
+	return true
+}
+
+// Err returns the error, if any, that was encountered while executing the
+// query or reading its rows.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Close closes the Iterator, so that it does not hold on to the database
+// connection if iteration is stopped early. It is safe to call Close more
+// than once.
+func (it *Iterator) Close() error {
+	if it.rows == nil {
+		return nil
+	}
+	return it.rows.Close()
+}
+
+// fail converts a value recovered from a panic raised by the mapper into the
+// error of the Iterator, and closes it.
+func (it *Iterator) fail(r interface{}) {
+	switch v := r.(type) {
+	case error:
+		it.err = v
+	case string:
+		it.err = errors.New(v)
+	}
+	it.Close()
+}
+
+// Collect executes the SelectQuery and appends the value returned by mapper
+// for every row to the slice that dest points to, e.g.
+//
+//	var users []User
+//	err := qy.Collect(q, db, &users, func(row qy.Row) interface{} {
+//	    return User{UID: row.Int64(u.UID), Name: row.String(u.DISPLAYNAME)}
+//	})
+//
+// Every value must be assignable to the element type of the slice. It takes
+// the place of a generic Collect[T], which Go 1.14 does not support.
+func Collect(q SelectQuery, db qx.Queryer, dest interface{}, mapper func(Row) interface{}) error {
+	slice := reflect.ValueOf(dest)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("Collect requires a pointer to a slice, got %T", dest)
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+	var value interface{}
+	return q.SelectxErr(func(row Row) {
+		value = mapper(row)
+	}, func() error {
+		v := reflect.ValueOf(value)
+		if !v.IsValid() {
+			v = reflect.Zero(elemType)
+		} else if !v.Type().AssignableTo(elemType) {
+			return fmt.Errorf("cannot collect a %s into a slice of %s", v.Type(), elemType)
+		}
+		slice.Set(reflect.Append(slice, v))
+		return nil
+	}).Exec(db)
+}
@@ -0,0 +1,105 @@
+package qy
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bokwoon95/qx-postgres/tables"
+	"github.com/matryer/is"
+)
+
+func TestSelectQuery_Iter(t *testing.T) {
+	is := is.New(t)
+	db, d := newFakeDB(t)
+	defer db.Close()
+	d.columns = []string{"uid", "displayname"}
+	d.rows = [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}, {int64(3), "c"}}
+	u := tables.USERS().As("u")
+	var uid int64
+	var name string
+	q := From(u).SelectRowx(func(row Row) {
+		uid = row.Int64(u.UID)
+		name = row.String(u.DISPLAYNAME)
+	})
+
+	// all rows
+	var names []string
+	it := q.Iter(context.Background(), db)
+	for it.Next() {
+		names = append(names, name)
+	}
+	is.NoErr(it.Err())
+	is.Equal([]string{"a", "b", "c"}, names)
+
+	// stopping early
+	it = q.Iter(context.Background(), db)
+	for it.Next() {
+		if uid == 2 {
+			break
+		}
+	}
+	is.NoErr(it.Close())
+	is.NoErr(it.Err())
+	is.Equal(int64(2), uid)
+
+	// no mapper
+	it = From(u).Iter(context.Background(), db)
+	is.True(!it.Next())
+	is.True(it.Err() != nil)
+}
+
+func TestSelectQuery_SelectxErr(t *testing.T) {
+	is := is.New(t)
+	db, d := newFakeDB(t)
+	defer db.Close()
+	d.columns = []string{"uid"}
+	d.rows = [][]driver.Value{{int64(1)}, {int64(2)}, {int64(3)}}
+	u := tables.USERS().As("u")
+	var uid int64
+	var uids []int64
+	mapper := func(row Row) { uid = row.Int64(u.UID) }
+	err := From(u).SelectxErr(mapper, func() error {
+		if uid == 2 {
+			return fmt.Errorf("done: %w", ErrStopIteration)
+		}
+		uids = append(uids, uid)
+		return nil
+	}).Exec(db)
+	is.NoErr(err)
+	is.Equal([]int64{1}, uids)
+
+	errTooMany := errors.New("too many")
+	err = From(u).SelectxErr(mapper, func() error {
+		if uid == 3 {
+			return errTooMany
+		}
+		return nil
+	}).Exec(db)
+	is.Equal(errTooMany, err)
+}
+
+func TestCollect(t *testing.T) {
+	is := is.New(t)
+	db, d := newFakeDB(t)
+	defer db.Close()
+	d.columns = []string{"uid", "displayname"}
+	d.rows = [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}}
+	u := tables.USERS().As("u")
+	type User struct {
+		UID  int64
+		Name string
+	}
+	mapper := func(row Row) interface{} {
+		return User{UID: row.Int64(u.UID), Name: row.String(u.DISPLAYNAME)}
+	}
+	var users []User
+	is.NoErr(Collect(From(u), db, &users, mapper))
+	is.Equal([]User{{1, "a"}, {2, "b"}}, users)
+
+	var names []string
+	is.True(Collect(From(u), db, &names, mapper) != nil) // wrong element type
+	is.True(Collect(From(u), db, users, mapper) != nil)  // not a pointer
+}
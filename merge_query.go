@@ -0,0 +1,361 @@
+package qy
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+)
+
+// MergeAction represents the action taken by a WHEN clause of a MERGE query.
+type MergeAction string
+
+// MergeActions
+const (
+	MergeActionUpdate    MergeAction = "UPDATE"
+	MergeActionDelete    MergeAction = "DELETE"
+	MergeActionInsert    MergeAction = "INSERT"
+	MergeActionDoNothing MergeAction = "DO NOTHING"
+)
+
+// MergeWhen represents a 'WHEN [NOT] MATCHED [AND condition] THEN action'
+// clause of a MERGE query.
+type MergeWhen struct {
+	Matched    bool
+	Predicates qx.VariadicPredicate
+	Action     MergeAction
+	// UPDATE SET
+	SetFields qx.FieldValueSets
+	// INSERT
+	InsertFields qx.Fields
+	ValuesList   qx.ValuesList
+}
+
+type MergeQuery struct {
+	Nested bool
+	Alias  string
+	// WITH
+	CTEs qx.CTEs
+	// MERGE INTO
+	IntoTable qx.BaseTable
+	// USING
+	UsingTable   qx.Table
+	OnPredicates qx.VariadicPredicate
+	// WHEN
+	WhenClauses []MergeWhen
+	// Parameters
+	ParamValues interface{}
+	// Logging
+	Log qx.Logger
+}
+
+func (q MergeQuery) ToSQL() (string, []interface{}) {
+	var buf = &strings.Builder{}
+	var args []interface{}
+	var excludeTableQualifiers []string
+	// WITH
+	q.CTEs.WriteSQL(buf, &args)
+	{ // MERGE INTO
+		intoQuery, intoArgs := "", []interface{}{}
+		if q.IntoTable != nil {
+			intoQuery, intoArgs = q.IntoTable.ToSQL()
+			if q.IntoTable.GetAlias() != "" {
+				excludeTableQualifiers = append(excludeTableQualifiers, q.IntoTable.GetAlias())
+			} else if q.IntoTable.GetName() != "" {
+				excludeTableQualifiers = append(excludeTableQualifiers, q.IntoTable.GetName())
+			}
+		}
+		if intoQuery != "" {
+			if buf.Len() > 0 {
+				buf.WriteString(" ")
+			}
+			if q.IntoTable.GetAlias() != "" {
+				buf.WriteString("MERGE INTO " + intoQuery + " AS " + q.IntoTable.GetAlias())
+			} else {
+				buf.WriteString("MERGE INTO " + intoQuery)
+			}
+			args = append(args, intoArgs...)
+		}
+	}
+	{ // USING
+		usingQuery, usingArgs := "", []interface{}{}
+		if query, ok := q.UsingTable.(qx.Query); ok && query != nil {
+			usingQuery, usingArgs = query.NestThis().ToSQL()
+			if usingQuery != "" {
+				usingQuery = "(" + usingQuery + ")"
+			}
+		} else if q.UsingTable != nil {
+			usingQuery, usingArgs = q.UsingTable.ToSQL()
+		}
+		if usingQuery != "" {
+			if buf.Len() > 0 {
+				buf.WriteString(" ")
+			}
+			if q.UsingTable.GetAlias() != "" {
+				buf.WriteString("USING " + usingQuery + " AS " + q.UsingTable.GetAlias())
+				if columnAliases := qx.ColumnAliasSQL(q.UsingTable); columnAliases != "" {
+					buf.WriteString(" " + columnAliases)
+				}
+			} else {
+				buf.WriteString("USING " + usingQuery)
+			}
+			args = append(args, usingArgs...)
+		}
+	}
+	// ON
+	q.OnPredicates.Toplevel = true
+	q.OnPredicates.WriteSQL(buf, &args, "ON ", "", nil)
+	// WHEN
+	for _, when := range q.WhenClauses {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		if when.Matched {
+			buf.WriteString("WHEN MATCHED")
+		} else {
+			buf.WriteString("WHEN NOT MATCHED")
+		}
+		when.Predicates.Toplevel = true
+		when.Predicates.WriteSQL(buf, &args, "AND ", "", nil)
+		switch when.Action {
+		case MergeActionUpdate:
+			// only the SET targets are stripped of their table qualifier, the
+			// values may refer to columns of both the target and the source
+			sets := make(qx.FieldValueSets, len(when.SetFields))
+			for i, set := range when.SetFields {
+				sets[i] = set
+				if set.Field == nil {
+					continue
+				}
+				target, _ := set.Field.ToSQL(excludeTableQualifiers)
+				if _, ok := set.Field.(qx.Fields); ok {
+					target = "(" + target + ")"
+				}
+				sets[i].Field = qx.FieldLiteral(target)
+			}
+			sets.WriteSQL(buf, &args, "THEN UPDATE SET ", "", nil)
+		case MergeActionDelete:
+			buf.WriteString(" THEN DELETE")
+		case MergeActionInsert:
+			buf.WriteString(" THEN INSERT")
+			when.InsertFields.WriteSQL(buf, &args, "(", ")", excludeTableQualifiers)
+			if !when.ValuesList.WriteSQL(buf, &args, "VALUES ", "") {
+				buf.WriteString(" DEFAULT VALUES")
+			}
+		default:
+			buf.WriteString(" THEN DO NOTHING")
+		}
+	}
+	query := buf.String()
+	if !q.Nested {
+		query = qx.MySQLToPostgresPlaceholders(query)
+		if q.Log != nil {
+			q.Log.Println(qx.PostgresInterpolateSQL(query, args...))
+		}
+	}
+	return query, args
+}
+
+func NewMergeQuery() MergeQuery {
+	return MergeQuery{Alias: qx.RandomString(8)}
+}
+
+func MergeInto(table qx.BaseTable) MergeQuery {
+	return NewMergeQuery().MergeInto(table)
+}
+
+func (q MergeQuery) With(ctes ...qx.CTE) MergeQuery {
+	q.CTEs = append(q.CTEs, ctes...)
+	return q
+}
+
+func (q MergeQuery) MergeInto(table qx.BaseTable) MergeQuery {
+	q.IntoTable = table
+	return q
+}
+
+// Using sets the source of the MergeQuery and the join condition used to
+// match source rows against the target table. The source can be any Table,
+// including a SelectQuery or a qx.ValuesTable.
+func (q MergeQuery) Using(table qx.Table, predicate qx.Predicate, predicates ...qx.Predicate) MergeQuery {
+	q.UsingTable = table
+	q.OnPredicates.Predicates = append([]qx.Predicate{predicate}, predicates...)
+	return q
+}
+
+// WhenMatched starts a 'WHEN MATCHED [AND predicates]' clause. The clause is
+// only added to the MergeQuery once one of its Then* or DoNothing methods is
+// called.
+func (q MergeQuery) WhenMatched(predicates ...qx.Predicate) mergeMatched {
+	return mergeMatched{
+		mergeQuery: &q,
+		when:       MergeWhen{Matched: true, Predicates: qx.VariadicPredicate{Predicates: predicates}},
+	}
+}
+
+// WhenNotMatched starts a 'WHEN NOT MATCHED [AND predicates]' clause. The
+// clause is only added to the MergeQuery once one of its Then* or DoNothing
+// methods is called.
+func (q MergeQuery) WhenNotMatched(predicates ...qx.Predicate) mergeNotMatched {
+	return mergeNotMatched{
+		mergeQuery: &q,
+		when:       MergeWhen{Predicates: qx.VariadicPredicate{Predicates: predicates}},
+	}
+}
+
+type mergeMatched struct {
+	mergeQuery *MergeQuery
+	when       MergeWhen
+}
+
+func (m mergeMatched) ThenUpdateSet(sets ...qx.FieldValueSet) MergeQuery {
+	m.when.Action = MergeActionUpdate
+	m.when.SetFields = sets
+	return m.mergeQuery.addWhen(m.when)
+}
+
+func (m mergeMatched) ThenDelete() MergeQuery {
+	m.when.Action = MergeActionDelete
+	return m.mergeQuery.addWhen(m.when)
+}
+
+func (m mergeMatched) DoNothing() MergeQuery {
+	m.when.Action = MergeActionDoNothing
+	return m.mergeQuery.addWhen(m.when)
+}
+
+type mergeNotMatched struct {
+	mergeQuery *MergeQuery
+	when       MergeWhen
+}
+
+// ThenInsert inserts a row built from the FieldValueSets, in the same way as
+// InsertQuery.InsertRow. If no FieldValueSets are provided the row consists
+// of only column defaults i.e. 'INSERT DEFAULT VALUES'.
+func (m mergeNotMatched) ThenInsert(sets ...qx.FieldValueSet) MergeQuery {
+	m.when.Action = MergeActionInsert
+	values := make([]interface{}, len(sets))
+	for i := range sets {
+		m.when.InsertFields = append(m.when.InsertFields, sets[i].Field)
+		values[i] = sets[i].Value
+	}
+	if len(values) > 0 {
+		m.when.ValuesList = qx.ValuesList{values}
+	}
+	return m.mergeQuery.addWhen(m.when)
+}
+
+func (m mergeNotMatched) DoNothing() MergeQuery {
+	m.when.Action = MergeActionDoNothing
+	return m.mergeQuery.addWhen(m.when)
+}
+
+func (q *MergeQuery) addWhen(when MergeWhen) MergeQuery {
+	if q == nil {
+		return MergeQuery{}
+	}
+	q.WhenClauses = append(q.WhenClauses[:len(q.WhenClauses):len(q.WhenClauses)], when)
+	return *q
+}
+
+// Validate checks the MergeQuery for mistakes that can be caught without
+// sending the query to the database. Exec calls Validate automatically.
+func (q MergeQuery) Validate() error {
+	if err := validateArgs(q); err != nil {
+		return err
+	}
+	if q.IntoTable == nil {
+		return errors.New("MERGE requires a target table")
+	}
+	if q.UsingTable == nil || len(q.OnPredicates.Predicates) == 0 {
+		return errors.New("MERGE requires a source table and a join condition")
+	}
+	if len(q.WhenClauses) == 0 {
+		return errors.New("MERGE requires at least one WHEN clause")
+	}
+	for _, when := range q.WhenClauses {
+		if when.Action == MergeActionUpdate && len(when.SetFields) == 0 {
+			return errors.New("WHEN MATCHED THEN UPDATE requires at least one SET field")
+		}
+	}
+	return validateNested(q.CTEs, q.UsingTable)
+}
+
+// Bind sets the values of the qx.Parameters in the MergeQuery, keyed by their
+// names. They are bound into the args when the query is executed.
+func (q MergeQuery) Bind(values map[string]interface{}) MergeQuery {
+	q.ParamValues = values
+	return q
+}
+
+// BindStruct is like Bind, but takes the values from the fields of a struct
+// (or pointer to a struct). Fields are matched by their `db` tag or, failing
+// that, their name.
+func (q MergeQuery) BindStruct(v interface{}) MergeQuery {
+	q.ParamValues = v
+	return q
+}
+
+// Exec executes the MergeQuery on the db.
+func (q MergeQuery) Exec(db qx.Queryer) error {
+	_, err := q.ExecRowsAffected(db)
+	return err
+}
+
+// ExecRowsAffected is like Exec, but also returns the number of rows
+// inserted, updated or deleted by the MergeQuery. If db implements qx.Execer
+// (as *sql.DB and *sql.Tx do), the query is executed with Exec instead of
+// Query and the count comes from its sql.Result. Otherwise the count cannot
+// be determined and -1 is returned.
+func (q MergeQuery) ExecRowsAffected(db qx.Queryer) (rowsAffected int64, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
+	if err = q.Validate(); err != nil {
+		return 0, err
+	}
+	query, args := q.ToSQL()
+	if args, err = qx.BindParams(args, q.ParamValues); err != nil {
+		return 0, err
+	}
+	if execer, ok := db.(qx.Execer); ok {
+		result, err := execer.Exec(query, args...)
+		if err != nil {
+			return 0, err
+		}
+		return result.RowsAffected()
+	}
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	if err = rows.Close(); err != nil {
+		return 0, err
+	}
+	return -1, rows.Err()
+}
+
+func (q MergeQuery) As(alias string) MergeQuery {
+	q.Alias = alias
+	return q
+}
+
+func (q MergeQuery) GetAlias() string {
+	return q.Alias
+}
+
+func (q MergeQuery) GetName() string {
+	return ""
+}
+
+func (q MergeQuery) NestThis() qx.Query {
+	q.Nested = true
+	return q
+}
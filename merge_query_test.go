@@ -0,0 +1,128 @@
+package qy
+
+import (
+	"testing"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+	"github.com/bokwoon95/qx-postgres/tables"
+	"github.com/matryer/is"
+)
+
+func TestMergeQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           MergeQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "merge from a table"
+			u, ur := tables.USERS().As("u"), tables.USER_ROLES().As("ur")
+			q := MergeInto(ur).
+				Using(u, u.UID.Eq(ur.UID)).
+				WhenMatched(u.PASSWORD.IsNull()).ThenDelete().
+				WhenMatched().ThenUpdateSet(ur.ROLE.SetString("student"), ur.UPDATED_AT.Set(Fieldf("NOW()"))).
+				WhenNotMatched().ThenInsert(ur.UID.Set(u.UID), ur.ROLE.SetString("applicant"))
+			wantQuery := "MERGE INTO public.user_roles AS ur USING public.users AS u ON u.uid = ur.uid" +
+				" WHEN MATCHED AND u.password IS NULL THEN DELETE" +
+				" WHEN MATCHED THEN UPDATE SET role = $1, updated_at = NOW()" +
+				" WHEN NOT MATCHED THEN INSERT (uid, role) VALUES (u.uid, $2)"
+			wantArgs := []interface{}{"student", "applicant"}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "merge from a subquery"
+			u, ur := tables.USERS().As("u"), tables.USER_ROLES().As("ur")
+			src := Select(u.UID, u.EMAIL).From(u).Where(u.DISPLAYNAME.EqString("aaa")).As("src")
+			q := MergeInto(ur).
+				Using(src, Predicatef("? = ?", ur.UID, src.Get("uid"))).
+				WhenMatched().DoNothing().
+				WhenNotMatched().ThenInsert(ur.UID.Set(src.Get("uid")), ur.ROLE.SetString("applicant"))
+			wantQuery := "MERGE INTO public.user_roles AS ur" +
+				" USING (SELECT u.uid, u.email FROM public.users AS u WHERE u.displayname = $1) AS src" +
+				" ON ur.uid = src.uid" +
+				" WHEN MATCHED THEN DO NOTHING" +
+				" WHEN NOT MATCHED THEN INSERT (uid, role) VALUES (src.uid, $2)"
+			wantArgs := []interface{}{"aaa", "applicant"}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "merge from a VALUES list"
+			u := tables.USERS().As("u")
+			v := qx.NewValuesTable(qx.ValuesList{{"aaa@email.com", "aaa"}, {"bbb@email.com", "bbb"}}, "v", "email", "displayname")
+			q := MergeInto(u).
+				Using(v, u.EMAIL.Eq(v.StringField("email"))).
+				WhenMatched(u.DISPLAYNAME.IsDistinctFrom(v.StringField("displayname"))).
+				ThenUpdateSet(u.DISPLAYNAME.Set(v.StringField("displayname"))).
+				WhenNotMatched().ThenInsert(u.EMAIL.Set(v.StringField("email")), u.DISPLAYNAME.Set(v.StringField("displayname")))
+			wantQuery := "MERGE INTO public.users AS u" +
+				" USING (VALUES ($1, $2), ($3, $4)) AS v (email, displayname) ON u.email = v.email" +
+				" WHEN MATCHED AND u.displayname IS DISTINCT FROM v.displayname THEN UPDATE SET displayname = v.displayname" +
+				" WHEN NOT MATCHED THEN INSERT (email, displayname) VALUES (v.email, v.displayname)"
+			wantArgs := []interface{}{"aaa@email.com", "aaa", "bbb@email.com", "bbb"}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "SET values keep their table qualifier"
+			u, ur := tables.USERS().As("u"), tables.USER_ROLES().As("ur")
+			q := MergeInto(ur).
+				Using(u, u.UID.Eq(ur.UID)).
+				WhenMatched().ThenUpdateSet(ur.UPDATED_AT.Set(ur.CREATED_AT), ur.UID.Set(u.UID))
+			wantQuery := "MERGE INTO public.user_roles AS ur USING public.users AS u ON u.uid = ur.uid" +
+				" WHEN MATCHED THEN UPDATE SET updated_at = ur.created_at, uid = u.uid"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
+		func() TT {
+			DESCRIPTION := "insert default values"
+			u, ur := tables.USERS().As("u"), tables.USER_ROLES().As("ur")
+			q := MergeInto(ur).Using(u, u.UID.Eq(ur.UID)).WhenNotMatched(u.UID.GtInt(5)).ThenInsert()
+			wantQuery := "MERGE INTO public.user_roles AS ur USING public.users AS u ON u.uid = ur.uid" +
+				" WHEN NOT MATCHED AND u.uid > $1 THEN INSERT DEFAULT VALUES"
+			wantArgs := []interface{}{5}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+			is.NoErr(tt.q.Validate())
+		})
+	}
+}
+
+func TestMergeQuery_Validate(t *testing.T) {
+	is := is.New(t)
+	u, ur := tables.USERS().As("u"), tables.USER_ROLES().As("ur")
+	is.True(MergeInto(ur).WhenMatched().ThenDelete().Validate() != nil)                               // no source
+	is.True(MergeInto(ur).Using(u, u.UID.Eq(ur.UID)).Validate() != nil)                               // no WHEN clauses
+	is.True(MergeInto(ur).Using(u, u.UID.Eq(ur.UID)).WhenMatched().ThenUpdateSet().Validate() != nil) // empty SET
+}
+
+func TestMergeQuery_ExecRowsAffected(t *testing.T) {
+	is := is.New(t)
+	db, d := newFakeDB(t)
+	defer db.Close()
+	u, ur := tables.USERS().As("u"), tables.USER_ROLES().As("ur")
+	q := MergeInto(ur).Using(u, u.UID.Eq(ur.UID)).WhenMatched().ThenDelete()
+	d.execRows = func(query string) int64 { return 3 }
+	rowsAffected, err := q.ExecRowsAffected(db)
+	is.NoErr(err)
+	is.Equal(int64(3), rowsAffected)
+	is.NoErr(q.Exec(db))
+
+	// a panic while building the query is returned as an error
+	boom := qx.CustomField{
+		Format: "?",
+		CustomSprintf: func(string, []interface{}, []string) (string, []interface{}) {
+			panic("boom")
+		},
+	}
+	err = MergeInto(ur).Using(u, u.UID.Eq(qx.NumberExpression(boom))).WhenMatched().ThenDelete().Exec(db)
+	is.Equal("boom", err.Error())
+}
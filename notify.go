@@ -0,0 +1,403 @@
+package qy
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+	"github.com/lib/pq"
+)
+
+// NotifyQuery sends a notification on a channel with 'SELECT pg_notify(channel,
+// payload)'. Unlike the NOTIFY statement, pg_notify accepts bind parameters.
+// Like NOTIFY, if it is executed inside a transaction the notification is
+// only delivered once the transaction commits.
+type NotifyQuery struct {
+	Channel string
+	Payload interface{}
+	// Logging
+	Log qx.Logger
+}
+
+// Notify returns a new NotifyQuery. If the payload is not a string or a
+// []byte, it is marshalled into JSON.
+func Notify(channel string, payload interface{}) NotifyQuery {
+	return NotifyQuery{
+		Channel: channel,
+		Payload: payload,
+	}
+}
+
+// ToSQL marshals the NotifyQuery into an SQL query and args. If the payload
+// cannot be marshalled into JSON, it is passed to the driver as is.
+func (q NotifyQuery) ToSQL() (string, []interface{}) {
+	payload, _ := q.payload()
+	query := "SELECT pg_notify($1, $2)"
+	args := []interface{}{q.Channel, payload}
+	if q.Log != nil {
+		q.Log.Println(qx.PostgresInterpolateSQL(query, args...))
+	}
+	return query, args
+}
+
+// payload returns the payload of the NotifyQuery as a string.
+func (q NotifyQuery) payload() (interface{}, error) {
+	switch v := q.Payload.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	}
+	b, err := json.Marshal(q.Payload)
+	if err != nil {
+		return q.Payload, err
+	}
+	return string(b), nil
+}
+
+// Exec sends the notification.
+func (q NotifyQuery) Exec(db qx.Queryer) error {
+	if q.Channel == "" {
+		return errors.New("NOTIFY requires a channel")
+	}
+	if _, err := q.payload(); err != nil {
+		return err
+	}
+	query, args := q.ToSQL()
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// Notification is a notification received by a Listener.
+type Notification struct {
+	Channel string
+	// Payload is the raw payload of the notification.
+	Payload string
+	// Value is the payload decoded into the type the Subscription was made
+	// with, or the raw payload if it was made without one.
+	Value interface{}
+	// Err is the error that occurred while decoding the payload, if any.
+	Err error
+	// Reconnected is set, and every other field except Channel is empty, if
+	// the Listener lost its connection and has just reconnected.
+	// Notifications sent while it was disconnected are lost.
+	Reconnected bool
+}
+
+// ListenerOptions configures a Listener.
+type ListenerOptions struct {
+	// MinReconnectInterval and MaxReconnectInterval bound how long the
+	// Listener waits before reconnecting after losing its connection. The
+	// wait doubles after each failed attempt. They default to 10 seconds
+	// and 1 minute.
+	MinReconnectInterval time.Duration
+	MaxReconnectInterval time.Duration
+
+	// PingInterval is how often the connection is checked if no
+	// notifications arrive, so that a dead connection is noticed and
+	// reconnected. Defaults to 90 seconds.
+	PingInterval time.Duration
+
+	// BufferSize is the size of the channel of each Subscription. Defaults
+	// to 32.
+	BufferSize int
+
+	// OnEvent, if not nil, is called whenever the state of the connection
+	// changes. It is passed to pq.NewListener.
+	OnEvent func(event pq.ListenerEventType, err error)
+}
+
+// notifier is the subset of *pq.Listener used by Listener.
+type notifier interface {
+	Listen(channel string) error
+	Unlisten(channel string) error
+	NotificationChannel() <-chan *pq.Notification
+	Ping() error
+	Close() error
+}
+
+// Listener receives notifications from Postgres and delivers them to the
+// Subscriptions for their channel. It is built on pq.Listener, which
+// reconnects automatically and listens on all subscribed channels again once
+// it does.
+//
+// A Subscription that is not read from will eventually block the delivery of
+// notifications to every other Subscription, so each one should be read from
+// its own goroutine.
+type Listener struct {
+	listener     notifier
+	pingInterval time.Duration
+	bufferSize   int
+	mu           sync.Mutex
+	subs         map[string][]*Subscription
+	// listenMu serialises the calls to Listen and Unlisten, which are made
+	// without holding mu. listening records the channels they have been
+	// called on and is only changed while holding both locks.
+	listenMu  sync.Mutex
+	listening map[string]bool
+	closing   chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewListener returns a new Listener that connects to the database with the
+// connection string dsn.
+func NewListener(dsn string, opts ListenerOptions) *Listener {
+	if opts.MinReconnectInterval <= 0 {
+		opts.MinReconnectInterval = 10 * time.Second
+	}
+	if opts.MaxReconnectInterval <= 0 {
+		opts.MaxReconnectInterval = time.Minute
+	}
+	var callback pq.EventCallbackType
+	if opts.OnEvent != nil {
+		callback = opts.OnEvent
+	}
+	return newListener(pq.NewListener(dsn, opts.MinReconnectInterval, opts.MaxReconnectInterval, callback), opts)
+}
+
+func newListener(listener notifier, opts ListenerOptions) *Listener {
+	if opts.PingInterval <= 0 {
+		opts.PingInterval = 90 * time.Second
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 32
+	}
+	l := &Listener{
+		listener:     listener,
+		pingInterval: opts.PingInterval,
+		bufferSize:   opts.BufferSize,
+		subs:         make(map[string][]*Subscription),
+		listening:    make(map[string]bool),
+		closing:      make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+// Subscription receives the notifications of one channel on C. It is created
+// with Listener.Subscribe.
+type Subscription struct {
+	C           <-chan Notification
+	c           chan Notification
+	listener    *Listener
+	channel     string
+	payloadType reflect.Type
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+// Subscribe listens on the channel and returns a Subscription that receives
+// its notifications. If payloadType is not nil, every payload is decoded
+// from JSON into a new value of the same type as payloadType, e.g.
+// Subscribe("status", StatusChange{}) delivers a StatusChange in each
+// Notification.Value. Otherwise Notification.Value holds the raw payload.
+func (l *Listener) Subscribe(channel string, payloadType interface{}) (*Subscription, error) {
+	c := make(chan Notification, l.bufferSize)
+	sub := &Subscription{
+		C:        c,
+		c:        c,
+		listener: l,
+		channel:  channel,
+		done:     make(chan struct{}),
+	}
+	if payloadType != nil {
+		sub.payloadType = reflect.TypeOf(payloadType)
+	}
+	l.mu.Lock()
+	select {
+	case <-l.closing:
+		l.mu.Unlock()
+		return nil, errors.New("listener is closed")
+	default:
+	}
+	l.subs[channel] = append(l.subs[channel], sub)
+	l.mu.Unlock()
+	if err := l.syncListen(channel); err != nil {
+		if l.remove(sub) {
+			l.syncListen(channel)
+		}
+		return nil, err
+	}
+	return sub, nil
+}
+
+// Close stops the Subscription and closes C. If it was the last Subscription
+// for its channel, the Listener stops listening on the channel.
+func (s *Subscription) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.done) // unblock any delivery in progress
+		if s.listener.remove(s) {
+			err = s.listener.syncListen(s.channel)
+		}
+	})
+	return err
+}
+
+// remove removes the Subscription from the Listener and closes its channel.
+// It reports whether the Subscription was removed, which it is not if
+// Listener.Close already removed it.
+func (l *Listener) remove(sub *Subscription) (removed bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	subs := l.subs[sub.channel]
+	for i := range subs {
+		if subs[i] != sub {
+			continue
+		}
+		subs = append(subs[:i:i], subs[i+1:]...)
+		close(sub.c)
+		if len(subs) == 0 {
+			delete(l.subs, sub.channel)
+		} else {
+			l.subs[sub.channel] = subs
+		}
+		return true
+	}
+	return false
+}
+
+// syncListen listens on the channel if it has any Subscriptions, or stops
+// listening on it if it has none. Since the calls are serialised and the
+// Subscriptions are checked only once it is their turn, a Subscribe racing
+// with the Close of the last Subscription for the same channel cannot be left
+// without a LISTEN.
+func (l *Listener) syncListen(channel string) error {
+	l.listenMu.Lock()
+	defer l.listenMu.Unlock()
+	l.mu.Lock()
+	select {
+	case <-l.closing:
+		l.mu.Unlock()
+		return nil
+	default:
+	}
+	wantListening := len(l.subs[channel]) > 0
+	listening := l.listening[channel]
+	l.mu.Unlock()
+	if wantListening == listening {
+		return nil
+	}
+	// Listen blocks while the listener is reconnecting, so it is called
+	// without holding mu
+	var err error
+	if wantListening {
+		if err = l.listener.Listen(channel); err == pq.ErrChannelAlreadyOpen {
+			err = nil
+		}
+	} else if err = l.listener.Unlisten(channel); err == pq.ErrChannelNotOpen {
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	if wantListening {
+		l.listening[channel] = true
+	} else {
+		delete(l.listening, channel)
+	}
+	l.mu.Unlock()
+	return nil
+}
+
+// Close stops the Listener, closes its connection and closes every
+// Subscription.
+func (l *Listener) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		close(l.closing)
+		err = l.listener.Close()
+		<-l.done
+		// the Subscriptions are closed without holding the lock, since a
+		// concurrent Subscription.Close takes the lock inside its closeOnce
+		var subs []*Subscription
+		l.mu.Lock()
+		for channel := range l.subs {
+			subs = append(subs, l.subs[channel]...)
+			delete(l.subs, channel)
+		}
+		l.mu.Unlock()
+		for _, sub := range subs {
+			sub.closeOnce.Do(func() {
+				close(sub.done)
+			})
+			close(sub.c)
+		}
+	})
+	return err
+}
+
+// run delivers notifications until the Listener is closed, pinging the
+// connection whenever it has been idle for the ping interval.
+func (l *Listener) run() {
+	defer close(l.done)
+	notifications := l.listener.NotificationChannel()
+	timer := time.NewTimer(l.pingInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case n, ok := <-notifications:
+			if !ok {
+				return
+			}
+			l.dispatch(n)
+		case <-timer.C:
+			go l.listener.Ping()
+		case <-l.closing:
+			return
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(l.pingInterval)
+	}
+}
+
+// dispatch delivers a notification to the Subscriptions of its channel. A
+// nil notification means that the connection was re-established, which is
+// reported to every Subscription.
+func (l *Listener) dispatch(n *pq.Notification) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if n == nil {
+		for channel, subs := range l.subs {
+			for _, sub := range subs {
+				l.deliver(sub, Notification{Channel: channel, Reconnected: true})
+			}
+		}
+		return
+	}
+	for _, sub := range l.subs[n.Channel] {
+		notification := Notification{Channel: n.Channel, Payload: n.Extra, Value: n.Extra}
+		if sub.payloadType != nil {
+			value := reflect.New(sub.payloadType)
+			notification.Err = json.Unmarshal([]byte(n.Extra), value.Interface())
+			notification.Value = value.Elem().Interface()
+		}
+		l.deliver(sub, notification)
+	}
+}
+
+// deliver sends the notification to the Subscription, unless the
+// Subscription or the Listener is closed first.
+func (l *Listener) deliver(sub *Subscription, notification Notification) {
+	select {
+	case sub.c <- notification:
+	case <-sub.done:
+	case <-l.closing:
+	}
+}
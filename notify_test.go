@@ -0,0 +1,223 @@
+package qy
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/matryer/is"
+)
+
+// fakeNotifier stands in for a *pq.Listener, recording the channels listened
+// on and delivering whatever is sent on c.
+type fakeNotifier struct {
+	mu        sync.Mutex
+	listening map[string]bool
+	c         chan *pq.Notification
+	closed    bool
+	// unlisten, if not nil, is called by Unlisten before it does anything
+	unlisten func()
+}
+
+func newFakeNotifier() *fakeNotifier {
+	return &fakeNotifier{listening: make(map[string]bool), c: make(chan *pq.Notification)}
+}
+
+func (n *fakeNotifier) Listen(channel string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.listening[channel] {
+		return pq.ErrChannelAlreadyOpen
+	}
+	n.listening[channel] = true
+	return nil
+}
+
+func (n *fakeNotifier) Unlisten(channel string) error {
+	if n.unlisten != nil {
+		n.unlisten()
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.listening[channel] {
+		return pq.ErrChannelNotOpen
+	}
+	delete(n.listening, channel)
+	return nil
+}
+
+func (n *fakeNotifier) isListening(channel string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.listening[channel]
+}
+
+func (n *fakeNotifier) NotificationChannel() <-chan *pq.Notification { return n.c }
+
+func (n *fakeNotifier) Ping() error { return nil }
+
+func (n *fakeNotifier) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.closed {
+		n.closed = true
+		close(n.c)
+	}
+	return nil
+}
+
+func TestNotify(t *testing.T) {
+	type TT struct {
+		payload     interface{}
+		wantPayload string
+	}
+	tests := []TT{
+		{nil, ""},
+		{"hello", "hello"},
+		{[]byte("hello"), "hello"},
+		{map[string]int{"id": 1}, `{"id":1}`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.wantPayload, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := Notify("events", tt.payload).ToSQL()
+			is.Equal("SELECT pg_notify($1, $2)", gotQuery)
+			is.Equal([]interface{}{"events", tt.wantPayload}, gotArgs)
+		})
+	}
+	t.Run("invalid payload", func(t *testing.T) {
+		t.Parallel()
+		is := is.New(t)
+		db, _ := newFakeDB(t)
+		is.True(Notify("events", make(chan int)).Exec(db) != nil)
+		is.True(Notify("", "hello").Exec(db) != nil)
+	})
+}
+
+func TestListener(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	type Event struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	n := newFakeNotifier()
+	l := newListener(n, ListenerOptions{PingInterval: time.Hour})
+
+	typed, err := l.Subscribe("events", Event{})
+	is.NoErr(err)
+	raw, err := l.Subscribe("events", nil)
+	is.NoErr(err)
+	other, err := l.Subscribe("other", nil)
+	is.NoErr(err)
+	is.True(n.isListening("events"))
+	is.True(n.isListening("other"))
+
+	n.c <- &pq.Notification{Channel: "events", Extra: `{"id":1,"name":"bob"}`}
+	got := <-typed.C
+	is.NoErr(got.Err)
+	is.Equal(Event{ID: 1, Name: "bob"}, got.Value)
+	got = <-raw.C
+	is.Equal(`{"id":1,"name":"bob"}`, got.Value)
+
+	n.c <- &pq.Notification{Channel: "events", Extra: "not json"}
+	got = <-typed.C
+	is.True(got.Err != nil)
+	is.Equal("not json", got.Payload)
+	<-raw.C
+
+	// a nil notification means the connection was re-established
+	n.c <- nil
+	is.True((<-typed.C).Reconnected)
+	is.True((<-raw.C).Reconnected)
+	is.Equal(Notification{Channel: "other", Reconnected: true}, <-other.C)
+
+	// the channel is only unlistened once its last subscription is closed
+	is.NoErr(typed.Close())
+	_, ok := <-typed.C
+	is.True(!ok)
+	is.True(n.isListening("events"))
+	is.NoErr(raw.Close())
+	is.True(!n.isListening("events"))
+	is.NoErr(raw.Close())
+
+	is.NoErr(l.Close())
+	_, ok = <-other.C
+	is.True(!ok)
+	_, err = l.Subscribe("events", nil)
+	is.True(err != nil)
+}
+
+func TestListener_Close(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	for i := 0; i < 100; i++ {
+		l := newListener(newFakeNotifier(), ListenerOptions{PingInterval: time.Hour})
+		var subs []*Subscription
+		for _, channel := range []string{"events", "events", "other"} {
+			sub, err := l.Subscribe(channel, nil)
+			is.NoErr(err)
+			subs = append(subs, sub)
+		}
+		// closing the Listener and its Subscriptions at the same time must
+		// neither deadlock nor close a channel twice
+		var wg sync.WaitGroup
+		for _, sub := range subs {
+			wg.Add(1)
+			go func(sub *Subscription) {
+				defer wg.Done()
+				sub.Close()
+			}(sub)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Close()
+		}()
+		wg.Wait()
+		for _, sub := range subs {
+			_, ok := <-sub.C
+			is.True(!ok)
+		}
+	}
+}
+
+func TestListener_Resubscribe(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	n := newFakeNotifier()
+	unlistening, release := make(chan struct{}), make(chan struct{})
+	n.unlisten = func() {
+		unlistening <- struct{}{}
+		<-release
+	}
+	l := newListener(n, ListenerOptions{PingInterval: time.Hour})
+	defer l.Close()
+	old, err := l.Subscribe("events", nil)
+	is.NoErr(err)
+
+	// subscribing while the last Subscription is being unlistened must leave
+	// the channel listened on
+	closed := make(chan error)
+	go func() { closed <- old.Close() }()
+	<-unlistening
+	subscribed := make(chan error)
+	var sub *Subscription
+	go func() {
+		var err error
+		sub, err = l.Subscribe("events", nil)
+		subscribed <- err
+	}()
+	time.Sleep(10 * time.Millisecond) // give Subscribe the chance to run ahead of Unlisten
+	close(release)
+	is.NoErr(<-closed)
+	is.NoErr(<-subscribed)
+	is.True(n.isListening("events"))
+
+	go func() { <-unlistening }()
+	is.NoErr(sub.Close())
+	is.True(!n.isListening("events"))
+}
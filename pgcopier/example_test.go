@@ -0,0 +1,28 @@
+package pgcopier_test
+
+import (
+	"context"
+	"log"
+	"os"
+
+	qy "github.com/bokwoon95/qx-postgres"
+	"github.com/bokwoon95/qx-postgres/pgcopier"
+	"github.com/bokwoon95/qx-postgres/tables"
+)
+
+func ExampleCopier() {
+	copier, err := pgcopier.Connect(context.Background(), os.Getenv("DATABASE_URL"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer copier.Close()
+	u := tables.USERS().As("u")
+	q := qy.Select(u.UID, u.EMAIL).From(u).Where(u.DISPLAYNAME.NeString(""))
+	rowcount, err := qy.CopyTo(os.Stdout, q, qy.CopyToOptions{DB: copier, Header: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("copied %d users", rowcount)
+}
+
+var _ qy.Copier = pgcopier.Copier{}
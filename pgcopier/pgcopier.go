@@ -0,0 +1,55 @@
+// Package pgcopier provides a qy.Copier backed by pgconn, for use with
+// qy.CopyTo. lib/pq cannot run 'COPY ... TO STDOUT', so CopyTo needs a
+// connection from a driver that can.
+package pgcopier
+
+import (
+	"context"
+	"io"
+
+	"github.com/jackc/pgconn"
+)
+
+// Copier streams COPY output over a pgconn connection. It implements
+// qy.Copier.
+type Copier struct {
+	Conn *pgconn.PgConn
+	// Ctx is the context the COPY is run with. Defaults to
+	// context.Background().
+	Ctx context.Context
+}
+
+// New returns a new Copier that runs COPY on the connection.
+func New(conn *pgconn.PgConn) Copier {
+	return Copier{Conn: conn}
+}
+
+// Connect opens a new connection to the database with the connection string
+// dsn, which accepts the same formats as lib/pq. The connection must be
+// closed with Close once it is no longer needed.
+func Connect(ctx context.Context, dsn string) (Copier, error) {
+	conn, err := pgconn.Connect(ctx, dsn)
+	if err != nil {
+		return Copier{}, err
+	}
+	return Copier{Conn: conn}, nil
+}
+
+// CopyTo runs the 'COPY ... TO STDOUT' query and writes its output into w. It
+// returns the number of rows copied.
+func (c Copier) CopyTo(w io.Writer, query string) (rowcount int64, err error) {
+	ctx := c.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	tag, err := c.Conn.CopyTo(ctx, w, query)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Close closes the underlying connection.
+func (c Copier) Close() error {
+	return c.Conn.Close(context.Background())
+}
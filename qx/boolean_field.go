@@ -1,6 +1,11 @@
 package qx
 
-// BooleanField either represents a boolean column or a literal bool value.
+import (
+	"github.com/lib/pq"
+)
+
+// BooleanField either represents a boolean column, a boolean
+// expression or a literal bool value.
 type BooleanField struct {
 	// BooleanField will be one of the following:
 
@@ -22,6 +27,13 @@ type BooleanField struct {
 	name       string
 	descending *bool
 	nullsfirst *bool
+
+	// 3) Boolean expression
+	// Examples of boolean expressions:
+	// | query                            | args |
+	// |----------------------------------|------|
+	// | (SELECT u.x FROM users AS u ...) |      |
+	expression Field
 }
 
 // ToSQL marshals a BooleanField into an SQL query and args (as described in
@@ -29,6 +41,16 @@ type BooleanField struct {
 // appears in the excludeTableQualifiers list, the output column name will not
 // be table qualified.
 func (f BooleanField) ToSQL(excludeTableQualifiers []string) (string, []interface{}) {
+	// 3) Boolean expression
+	if f.expression != nil {
+		return CustomField{
+			Format:       "?",
+			Values:       []interface{}{f.expression},
+			IsDesc:       f.descending,
+			IsNullsFirst: f.nullsfirst,
+		}.ToSQL(excludeTableQualifiers)
+	}
+
 	// 1) Literal bool value
 	if f.value != nil {
 		return "?", []interface{}{*f.value}
@@ -77,6 +99,15 @@ func NewBooleanField(name string, table *TableInfo) BooleanField {
 	return f
 }
 
+// BooleanExpression returns a new BooleanField representing an arbitrary Field
+// (such as a scalar Subquery), so that it can be used wherever a BooleanField
+// is expected.
+func BooleanExpression(field Field) BooleanField {
+	return BooleanField{
+		expression: field,
+	}
+}
+
 // Bool returns a new Boolean Field representing a literal bool value.
 func Bool(b bool) BooleanField {
 	return BooleanField{
@@ -106,6 +137,13 @@ func (f BooleanField) As(alias string) BooleanField {
 	return f
 }
 
+// Excluded returns a new BooleanField that references the row proposed for
+// insertion in an INSERT ... ON CONFLICT DO UPDATE query i.e. 'EXCLUDED.field'.
+func (f BooleanField) Excluded() BooleanField {
+	f.table = &TableInfo{Alias: "EXCLUDED"}
+	return f
+}
+
 // Asc returns a new BooleanField indicating that it should be ordered in
 // ascending order i.e. 'ORDER BY field ASC'.
 func (f BooleanField) Asc() BooleanField {
@@ -180,6 +218,96 @@ func (f BooleanField) Ne(field BooleanField) Predicate {
 	}
 }
 
+// InBool returns an 'A IN (B1, B2, B3...)' Predicate. It only accepts []bool.
+func (f BooleanField) InBool(bools []bool) InPredicate {
+	values := make([]interface{}, len(bools))
+	for i := range bools {
+		values[i] = bools[i]
+	}
+	return InPredicate{
+		Field:     f,
+		Values:    values,
+		Array:     pq.BoolArray(bools),
+		ArrayType: "BOOLEAN",
+	}
+}
+
+// NotInBool returns an 'A NOT IN (B1, B2, B3...)' Predicate. It only accepts
+// []bool.
+func (f BooleanField) NotInBool(bools []bool) InPredicate {
+	p := f.InBool(bools)
+	p.Negative = true
+	return p
+}
+
+// IsDistinctFrom returns an 'A IS DISTINCT FROM B' Predicate. It only accepts
+// BooleanField.
+func (f BooleanField) IsDistinctFrom(field BooleanField) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsDistinctFrom,
+		LeftField:  f,
+		RightField: field,
+	}
+}
+
+// IsNotDistinctFrom returns an 'A IS NOT DISTINCT FROM B' Predicate. It only
+// accepts BooleanField.
+func (f BooleanField) IsNotDistinctFrom(field BooleanField) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsNotDistinctFrom,
+		LeftField:  f,
+		RightField: field,
+	}
+}
+
+// IsTrue returns an 'A IS TRUE' Predicate.
+func (f BooleanField) IsTrue() Predicate {
+	return UnaryPredicate{
+		Operator: PredicateIsTrue,
+		Field:    f,
+	}
+}
+
+// IsNotTrue returns an 'A IS NOT TRUE' Predicate.
+func (f BooleanField) IsNotTrue() Predicate {
+	return UnaryPredicate{
+		Operator: PredicateIsNotTrue,
+		Field:    f,
+	}
+}
+
+// IsFalse returns an 'A IS FALSE' Predicate.
+func (f BooleanField) IsFalse() Predicate {
+	return UnaryPredicate{
+		Operator: PredicateIsFalse,
+		Field:    f,
+	}
+}
+
+// IsNotFalse returns an 'A IS NOT FALSE' Predicate.
+func (f BooleanField) IsNotFalse() Predicate {
+	return UnaryPredicate{
+		Operator: PredicateIsNotFalse,
+		Field:    f,
+	}
+}
+
+// IsUnknown returns an 'A IS UNKNOWN' Predicate.
+func (f BooleanField) IsUnknown() Predicate {
+	return UnaryPredicate{
+		Operator: PredicateIsUnknown,
+		Field:    f,
+	}
+}
+
+// IsNotUnknown returns an 'A IS NOT UNKNOWN' Predicate.
+func (f BooleanField) IsNotUnknown() Predicate {
+	return UnaryPredicate{
+		Operator: PredicateIsNotUnknown,
+		Field:    f,
+	}
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of a BooleanField.
 func (f BooleanField) String() string {
@@ -60,6 +60,12 @@ func TestBooleanField_ToSQL(t *testing.T) {
 			wantQuery := "is_user"
 			return TT{DESCRIPTION, f, excludeTableQualifiers, wantQuery, nil}
 		}(),
+		func() TT {
+			DESCRIPTION := "boolean expression"
+			f := BooleanExpression(CustomField{Format: "EXISTS (SELECT 1 FROM users WHERE uid = ?)", Values: []interface{}{5}})
+			wantQuery := "EXISTS (SELECT 1 FROM users WHERE uid = ?)"
+			return TT{DESCRIPTION, f, nil, wantQuery, []interface{}{5}}
+		}(),
 		func() TT {
 			DESCRIPTION := "ASC NULLS LAST"
 			tbl := NewTableInfo("public", "users")
@@ -110,6 +116,16 @@ func TestBooleanField_GameTheNumbers(t *testing.T) {
 	f.IsNotNull()
 	f.Eq(f)
 	f.Ne(f)
+	f.InBool([]bool{true})
+	f.NotInBool([]bool{false})
+	f.IsDistinctFrom(f)
+	f.IsNotDistinctFrom(f)
+	f.IsTrue()
+	f.IsNotTrue()
+	f.IsFalse()
+	f.IsNotFalse()
+	f.IsUnknown()
+	f.IsNotUnknown()
 	// fmt.Stringer
 	fmt.Println(f)
 }
@@ -0,0 +1,287 @@
+package qx
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SelectLister is implemented by Queries that can list the Fields in their
+// select list. It is used to validate access to the output columns of
+// subqueries, CTEs and set operations.
+type SelectLister interface {
+	GetSelectFields() []Field
+}
+
+// OutputColumnName returns the name of the column that the Field produces
+// when it appears in a select list i.e. its alias if it has one, otherwise
+// its column name. It returns an empty string if the name is decided by the
+// database (e.g. an unaliased expression).
+func OutputColumnName(field Field) string {
+	if field == nil {
+		return ""
+	}
+	if alias := field.GetAlias(); alias != "" {
+		return alias
+	}
+	switch field.(type) {
+	case NumberField, StringField, TimeField, BooleanField, JSONField:
+		// literal values and expressions have an empty name
+		return field.GetName()
+	}
+	return ""
+}
+
+// CheckColumn returns an error if the Table lists its select Fields and none
+// of them produce a column called name. If wantType is not nil, it also
+// returns an error if the matching Field is a typed field of a different
+// type. If the output names cannot all be determined, unknown names are given
+// the benefit of the doubt.
+func CheckColumn(table Table, name string, wantType Field) error {
+	lister, ok := table.(SelectLister)
+	if !ok {
+		return nil
+	}
+	fields := lister.GetSelectFields()
+	if len(fields) == 0 {
+		// the select list may be populated later, e.g. by a mapper
+		return nil
+	}
+	var undetermined bool
+	var match Field
+	for _, field := range flattenFields(fields) {
+		outputName := OutputColumnName(field)
+		if outputName == "" {
+			undetermined = true
+			continue
+		}
+		if outputName != name {
+			continue
+		}
+		if match != nil {
+			return fmt.Errorf("column %s of %s is ambiguous: %s and %s both produce it", name, tableQualifier(table), match, field)
+		}
+		match = field
+	}
+	if match == nil {
+		if undetermined {
+			return nil
+		}
+		return fmt.Errorf("%s has no column called %s", tableQualifier(table), name)
+	}
+	if wantType == nil {
+		return nil
+	}
+	switch match.(type) {
+	case NumberField, StringField, TimeField, BooleanField, JSONField:
+		if reflect.TypeOf(match) != reflect.TypeOf(wantType) {
+			return fmt.Errorf("column %s of %s is a %T, not a %T", name, tableQualifier(table), match, wantType)
+		}
+	}
+	return nil
+}
+
+// flattenFields expands any Fields nested in the list (such as the ones
+// returned by TableInfo.Star) so that every column appears individually.
+func flattenFields(fields []Field) []Field {
+	var flattened []Field
+	for _, field := range fields {
+		if nested, ok := field.(Fields); ok {
+			flattened = append(flattened, flattenFields(nested)...)
+			continue
+		}
+		flattened = append(flattened, field)
+	}
+	return flattened
+}
+
+// CheckDuplicateColumns returns an error if two or more of the Fields produce
+// an output column with the same name, e.g. 'SELECT u.uid, ur.uid'. Postgres
+// accepts such a select list, but any reference to the column from outside
+// the query (as a subquery, CTE or RETURNING row) is ambiguous. Fields whose
+// output names are decided by the database are not checked.
+func CheckDuplicateColumns(fields []Field) error {
+	seen := make(map[string]Field)
+	for _, field := range flattenFields(fields) {
+		name := OutputColumnName(field)
+		if name == "" {
+			continue
+		}
+		if previous, ok := seen[name]; ok {
+			return fmt.Errorf("duplicate output column %s: %s and %s both produce it (use As or AliasDuplicateColumns to disambiguate)", name, previous, field)
+		}
+		seen[name] = field
+	}
+	return nil
+}
+
+// AliasDuplicateColumns returns a copy of the Fields where every table column
+// that shares its output name with another Field is aliased as
+// 'tablealias_column', e.g. 'SELECT u.uid AS u_uid, ur.uid AS ur_uid'. Fields
+// that are already aliased, or that are not qualified by a table, are left as
+// they are.
+func AliasDuplicateColumns(fields []Field) []Field {
+	fields = flattenFields(fields)
+	counts := make(map[string]int)
+	for _, field := range fields {
+		if name := OutputColumnName(field); name != "" {
+			counts[name]++
+		}
+	}
+	aliased := make([]Field, len(fields))
+	for i, field := range fields {
+		aliased[i] = field
+		if field.GetAlias() != "" || counts[OutputColumnName(field)] < 2 {
+			continue
+		}
+		query, _ := field.ToSQL(nil)
+		dot := strings.LastIndex(query, ".")
+		if dot < 0 || query[dot+1:] != field.GetName() {
+			continue
+		}
+		alias := strings.Replace(query[:dot], ".", "_", -1) + "_" + field.GetName()
+		switch f := field.(type) {
+		case NumberField:
+			aliased[i] = f.As(alias)
+		case StringField:
+			aliased[i] = f.As(alias)
+		case TimeField:
+			aliased[i] = f.As(alias)
+		case BooleanField:
+			aliased[i] = f.As(alias)
+		case JSONField:
+			aliased[i] = f.As(alias)
+		}
+	}
+	return aliased
+}
+
+// tableQualifier returns the alias of the Table, or its name if it has no
+// alias.
+func tableQualifier(table Table) string {
+	if alias := table.GetAlias(); alias != "" {
+		return alias
+	}
+	return table.GetName()
+}
+
+// ColumnError is a Field standing in for an output column that could not be
+// referenced, because CheckColumn reported an error. Like a Parameter, it is
+// rendered as a placeholder with itself as the arg, so that the error is
+// reported by CheckArgs when the query is validated or executed instead of
+// while the query is being built.
+type ColumnError struct {
+	Name string
+	Err  error
+}
+
+// ToSQL marshals a ColumnError into a placeholder and the ColumnError itself.
+func (e ColumnError) ToSQL([]string) (string, []interface{}) {
+	return "?", []interface{}{e}
+}
+
+// GetAlias implements the Field interface. It always returns an empty string.
+func (e ColumnError) GetAlias() string {
+	return ""
+}
+
+// GetName implements the Field interface. It returns the name of the column.
+func (e ColumnError) GetName() string {
+	return e.Name
+}
+
+func (e ColumnError) Error() string {
+	return e.Err.Error()
+}
+
+// Value implements the driver.Valuer interface. It always returns the error,
+// so that a query with a ColumnError fails even if it is executed without
+// being validated.
+func (e ColumnError) Value() (driver.Value, error) {
+	return nil, e.Err
+}
+
+// CheckArgs returns the first ColumnError or ConvertError in the args of a
+// query, if any.
+func CheckArgs(args []interface{}) error {
+	for _, arg := range args {
+		switch e := arg.(type) {
+		case ColumnError:
+			return e
+		case ConvertError:
+			return e
+		}
+	}
+	return nil
+}
+
+// NumberColumn returns a NumberField referencing the output column of the
+// Table i.e. 'alias.name'. If CheckColumn reports an error, the NumberField
+// holds a ColumnError instead.
+func NumberColumn(table Table, name string) NumberField {
+	if err := CheckColumn(table, name, NumberField{}); err != nil {
+		return NumberExpression(ColumnError{Name: name, Err: err})
+	}
+	return NewNumberField(name, &TableInfo{Alias: tableQualifier(table)})
+}
+
+// StringColumn is like NumberColumn, but returns a StringField.
+func StringColumn(table Table, name string) StringField {
+	if err := CheckColumn(table, name, StringField{}); err != nil {
+		return StringExpression(ColumnError{Name: name, Err: err})
+	}
+	return NewStringField(name, &TableInfo{Alias: tableQualifier(table)})
+}
+
+// TimeColumn is like NumberColumn, but returns a TimeField.
+func TimeColumn(table Table, name string) TimeField {
+	if err := CheckColumn(table, name, TimeField{}); err != nil {
+		return TimeExpression(ColumnError{Name: name, Err: err})
+	}
+	return NewTimeField(name, &TableInfo{Alias: tableQualifier(table)})
+}
+
+// BooleanColumn is like NumberColumn, but returns a BooleanField.
+func BooleanColumn(table Table, name string) BooleanField {
+	if err := CheckColumn(table, name, BooleanField{}); err != nil {
+		return BooleanExpression(ColumnError{Name: name, Err: err})
+	}
+	return NewBooleanField(name, &TableInfo{Alias: tableQualifier(table)})
+}
+
+// JSONColumn is like NumberColumn, but returns a JSONField.
+func JSONColumn(table Table, name string) JSONField {
+	if err := CheckColumn(table, name, JSONField{}); err != nil {
+		return JSONExpression(ColumnError{Name: name, Err: err})
+	}
+	return NewJSONField(name, &TableInfo{Alias: tableQualifier(table)})
+}
+
+// ColumnOf returns a Field referencing the output column of the Table that is
+// produced by the field in its select list. The returned Field has the same
+// type as field, so it can be type asserted back e.g.
+// ColumnOf(q, u.UID).(NumberField). If the field has no name or alias, or if
+// CheckColumn reports an error, the returned Field holds a ColumnError.
+func ColumnOf(table Table, field Field) Field {
+	name := OutputColumnName(field)
+	if name == "" {
+		return ColumnError{Err: fmt.Errorf("%s has no name or alias", field)}
+	}
+	switch field.(type) {
+	case NumberField:
+		return NumberColumn(table, name)
+	case StringField:
+		return StringColumn(table, name)
+	case TimeField:
+		return TimeColumn(table, name)
+	case BooleanField:
+		return BooleanColumn(table, name)
+	case JSONField:
+		return JSONColumn(table, name)
+	}
+	if err := CheckColumn(table, name, nil); err != nil {
+		return ColumnError{Name: name, Err: err}
+	}
+	return CustomField{Format: tableQualifier(table) + "." + name}
+}
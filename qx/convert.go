@@ -0,0 +1,83 @@
+package qx
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ConvertValue converts a Go value destined for the column represented by
+// field into a value that the database driver accepts. Slices become Postgres
+// arrays (e.g. []int64 becomes pq.Int64Array), and maps, structs and slices
+// destined for a JSONField are marshalled into JSON. Values the driver already
+// understands (including Fields, DefaultKeyword and driver.Valuers) are
+// returned unchanged.
+func ConvertValue(field Field, value interface{}) (interface{}, error) {
+	switch value.(type) {
+	case nil, Field, DefaultKeyword, driver.Valuer, []byte, string, bool, time.Time,
+		int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return value, nil
+	}
+	if _, ok := field.(JSONField); ok {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+	switch v := value.(type) {
+	case []int:
+		array := make(pq.Int64Array, len(v))
+		for i := range v {
+			array[i] = int64(v[i])
+		}
+		return array, nil
+	case []int64:
+		return pq.Int64Array(v), nil
+	case []float64:
+		return pq.Float64Array(v), nil
+	case []string:
+		return pq.StringArray(v), nil
+	case []bool:
+		return pq.BoolArray(v), nil
+	}
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Slice, reflect.Array:
+		return pq.GenericArray{A: value}, nil
+	case reflect.Map, reflect.Struct:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+	return value, nil
+}
+
+// ConvertError is stored in place of a value that ConvertValue could not
+// convert, so that the error is reported by CheckArgs when the query is
+// validated or executed instead of while the query is being built.
+type ConvertError struct {
+	Name string // the name of the field the value was destined for
+	Err  error
+}
+
+func (e ConvertError) Error() string {
+	return e.Name + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error returned by ConvertValue.
+func (e ConvertError) Unwrap() error {
+	return e.Err
+}
+
+// Value implements the driver.Valuer interface. It always returns the error,
+// so that a query with a ConvertError fails even if it is executed without
+// being validated.
+func (e ConvertError) Value() (driver.Value, error) {
+	return nil, e
+}
@@ -0,0 +1,38 @@
+package qx
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/matryer/is"
+)
+
+func TestConvertValue(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		field       Field
+		value       interface{}
+		wantValue   interface{}
+	}
+	u := USERS()
+	tests := []TT{
+		{"string", u.DISPLAYNAME, "bob", "bob"},
+		{"nil", u.DISPLAYNAME, nil, nil},
+		{"Default", u.UID, Default, Default},
+		{"[]int", u.UID, []int{1, 2}, pq.Int64Array{1, 2}},
+		{"[]string", u.DISPLAYNAME, []string{"a", "b"}, pq.StringArray{"a", "b"}},
+		{"other slice", u.UID, []int32{1, 2}, pq.GenericArray{A: []int32{1, 2}}},
+		{"map", u.DISPLAYNAME, map[string]int{"a": 1}, `{"a":1}`},
+		{"slice into a JSONField", NewJSONField("data", u.TableInfo), []int{1, 2}, `[1,2]`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotValue, err := ConvertValue(tt.field, tt.value)
+			is.NoErr(err)
+			is.Equal(tt.wantValue, gotValue)
+		})
+	}
+}
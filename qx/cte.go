@@ -40,6 +40,49 @@ func (cte CTE) Get(fieldName string) CustomField {
 	return CustomField{Format: cte.Name + "." + fieldName}
 }
 
+// GetSelectFields implements the SelectLister interface. It returns the select
+// Fields of the underlying Query, if known.
+func (cte CTE) GetSelectFields() []Field {
+	if lister, ok := cte.Query.(SelectLister); ok {
+		return lister.GetSelectFields()
+	}
+	return nil
+}
+
+// GetNumber returns a NumberField referencing the column of the CTE
+// identified by name. If the name is not in the select list of the
+// CTE, or belongs to a Field of a different type, the error is
+// reported when the query is validated or executed.
+func (cte CTE) GetNumber(name string) NumberField {
+	return NumberColumn(cte, name)
+}
+
+// GetString is like GetNumber, but returns a StringField.
+func (cte CTE) GetString(name string) StringField {
+	return StringColumn(cte, name)
+}
+
+// GetTime is like GetNumber, but returns a TimeField.
+func (cte CTE) GetTime(name string) TimeField {
+	return TimeColumn(cte, name)
+}
+
+// GetBoolean is like GetNumber, but returns a BooleanField.
+func (cte CTE) GetBoolean(name string) BooleanField {
+	return BooleanColumn(cte, name)
+}
+
+// GetJSON is like GetNumber, but returns a JSONField.
+func (cte CTE) GetJSON(name string) JSONField {
+	return JSONColumn(cte, name)
+}
+
+// Field returns a Field referencing the column of the CTE that is produced
+// by the field in its select list, as described in ColumnOf.
+func (cte CTE) Field(field Field) Field {
+	return ColumnOf(cte, field)
+}
+
 // CTEs represents a list of CTEs
 type CTEs []CTE
 
@@ -75,12 +118,13 @@ func (ctes CTEs) WriteSQL(buf *strings.Builder, args *[]interface{}) (written bo
 type AliasedCTE struct {
 	Name  string
 	Alias string
+	Query Query
 }
 
 // As returns a an Aliased CTE derived from the parent CTE that it was called
 // on.
 func (cte CTE) As(alias string) AliasedCTE {
-	return AliasedCTE{Name: cte.Name, Alias: alias}
+	return AliasedCTE{Name: cte.Name, Alias: alias, Query: cte.Query}
 }
 
 // ToSQL returns the name of the parent CTE the AliasedCTE was derived from.
@@ -108,3 +152,46 @@ func (cte AliasedCTE) GetName() string {
 func (cte AliasedCTE) Get(fieldName string) CustomField {
 	return CustomField{Format: cte.Alias + "." + fieldName}
 }
+
+// GetSelectFields implements the SelectLister interface. It returns the select
+// Fields of the underlying Query, if known.
+func (cte AliasedCTE) GetSelectFields() []Field {
+	if lister, ok := cte.Query.(SelectLister); ok {
+		return lister.GetSelectFields()
+	}
+	return nil
+}
+
+// GetNumber returns a NumberField referencing the column of the AliasedCTE
+// identified by name. If the name is not in the select list of the
+// AliasedCTE, or belongs to a Field of a different type, the error is
+// reported when the query is validated or executed.
+func (cte AliasedCTE) GetNumber(name string) NumberField {
+	return NumberColumn(cte, name)
+}
+
+// GetString is like GetNumber, but returns a StringField.
+func (cte AliasedCTE) GetString(name string) StringField {
+	return StringColumn(cte, name)
+}
+
+// GetTime is like GetNumber, but returns a TimeField.
+func (cte AliasedCTE) GetTime(name string) TimeField {
+	return TimeColumn(cte, name)
+}
+
+// GetBoolean is like GetNumber, but returns a BooleanField.
+func (cte AliasedCTE) GetBoolean(name string) BooleanField {
+	return BooleanColumn(cte, name)
+}
+
+// GetJSON is like GetNumber, but returns a JSONField.
+func (cte AliasedCTE) GetJSON(name string) JSONField {
+	return JSONColumn(cte, name)
+}
+
+// Field returns a Field referencing the column of the AliasedCTE that is produced
+// by the field in its select list, as described in ColumnOf.
+func (cte AliasedCTE) Field(field Field) Field {
+	return ColumnOf(cte, field)
+}
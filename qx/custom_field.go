@@ -152,6 +152,69 @@ func (f CustomField) Le(field Field) Predicate {
 	}
 }
 
+// IsDistinctFrom returns an 'A IS DISTINCT FROM B' Predicate. It accepts any
+// Field.
+func (f CustomField) IsDistinctFrom(field Field) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsDistinctFrom,
+		LeftField:  f,
+		RightField: field,
+	}
+}
+
+// IsNotDistinctFrom returns an 'A IS NOT DISTINCT FROM B' Predicate. It accepts
+// any Field.
+func (f CustomField) IsNotDistinctFrom(field Field) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsNotDistinctFrom,
+		LeftField:  f,
+		RightField: field,
+	}
+}
+
+// Between returns an 'A BETWEEN X AND Y' Predicate. It accepts any Field.
+func (f CustomField) Between(start, end Field) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateBetween,
+		Field:    f,
+		FieldX:   start,
+		FieldY:   end,
+	}
+}
+
+// NotBetween returns an 'A NOT BETWEEN X AND Y' Predicate. It accepts any
+// Field.
+func (f CustomField) NotBetween(start, end Field) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateNotBetween,
+		Field:    f,
+		FieldX:   start,
+		FieldY:   end,
+	}
+}
+
+// BetweenSymmetric returns an 'A BETWEEN SYMMETRIC X AND Y' Predicate.
+// It accepts any Field.
+func (f CustomField) BetweenSymmetric(start, end Field) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateBetweenSymmetric,
+		Field:    f,
+		FieldX:   start,
+		FieldY:   end,
+	}
+}
+
+// NotBetweenSymmetric returns an 'A NOT BETWEEN SYMMETRIC X AND Y' Predicate.
+// It accepts any Field.
+func (f CustomField) NotBetweenSymmetric(start, end Field) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateNotBetweenSymmetric,
+		Field:    f,
+		FieldX:   start,
+		FieldY:   end,
+	}
+}
+
 // GetAlias implements the Field interface. It returns the alias of thee
 // CustomField.
 func (f CustomField) GetAlias() string {
@@ -66,6 +66,38 @@ func (fs Fields) WriteSQL(buf *strings.Builder, args *[]interface{}, prependWith
 	return false
 }
 
+// ToSQL implements the Field interface. It marshals the Fields into a
+// "field1, field2, etc..." list, which lets a parenthesized list of columns
+// be assigned to in a multi-column SET i.e. 'SET (field1, field2) = ...'.
+func (fs Fields) ToSQL(excludeTableQualifiers []string) (string, []interface{}) {
+	buf := &strings.Builder{}
+	var args []interface{}
+	fs.WriteSQL(buf, &args, "", "", excludeTableQualifiers)
+	return buf.String(), args
+}
+
+// GetAlias implements the Field interface. It always returns an empty string
+// because Fields do not have aliases.
+func (fs Fields) GetAlias() string {
+	return ""
+}
+
+// GetName implements the Field interface. It always returns an empty string
+// because Fields do not have names.
+func (fs Fields) GetName() string {
+	return ""
+}
+
+// Set returns a FieldValueSet associating the Fields to the value i.e.
+// 'SET (field1, field2) = value'. The value is usually a subquery or a
+// RowValue.
+func (fs Fields) Set(val interface{}) FieldValueSet {
+	return FieldValueSet{
+		Field: fs,
+		Value: val,
+	}
+}
+
 // WriteSQLWithAlias is exactly like WriteSQL, but appends each field (i.e.
 // field1 AS alias1, field2 AS alias2, ...) with its alias if it has one.
 func (fs Fields) WriteSQLWithAlias(buf *strings.Builder, args *[]interface{}, prependWith, appendWith string, excludeTableQualifiers []string) (written bool) {
@@ -123,7 +155,16 @@ func (sets FieldValueSets) WriteSQL(buf *strings.Builder, args *[]interface{}, p
 		if subquery == "" {
 			continue
 		}
-		if field, ok := sets[i].Value.(Field); ok && field != nil {
+		if _, ok := sets[i].Field.(Fields); ok {
+			subquery = "(" + subquery + ")"
+		}
+		if _, ok := sets[i].Value.(DefaultKeyword); ok {
+			subquery = subquery + " = DEFAULT"
+		} else if query, ok := sets[i].Value.(Query); ok && query != nil {
+			q, a := query.NestThis().ToSQL()
+			subquery = subquery + " = (" + q + ")"
+			subargs = append(subargs, a...)
+		} else if field, ok := sets[i].Value.(Field); ok && field != nil {
 			q, a := field.ToSQL(excludeTableQualifiers)
 			subquery = subquery + " = " + q
 			subargs = append(subargs, a...)
@@ -0,0 +1,210 @@
+package qx
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// FunctionTable is a Table that represents a set-returning function call used
+// in the FROM or JOIN clauses i.e. 'function(args...) [WITH ORDINALITY] AS
+// alias (col1, col2...)'. Arguments that are Fields are expanded in place,
+// everything else becomes a '?' placeholder.
+//
+// For functions returning the record type (e.g. jsonb_to_recordset) the
+// Columns must be a column definition list, where each column carries its
+// type e.g. "uid INT".
+type FunctionTable struct {
+	Function   string
+	Args       []interface{}
+	Ordinality bool
+	Alias      string
+	Columns    []string
+}
+
+// Function returns a new FunctionTable calling the set-returning function
+// with the args.
+func Function(function string, args ...interface{}) FunctionTable {
+	return FunctionTable{Function: function, Args: args}
+}
+
+// GenerateSeries returns a new FunctionTable calling generate_series(start,
+// stop). Go ints, floats and time.Times are cast to BIGINT, NUMERIC and
+// TIMESTAMPTZ respectively so that postgres can pick which generate_series
+// to call.
+func GenerateSeries(start, stop interface{}) FunctionTable {
+	return Function("generate_series", seriesArg(start), seriesArg(stop))
+}
+
+// GenerateSeriesStep returns a new FunctionTable calling
+// generate_series(start, stop, step). For timestamps the step should be an
+// interval e.g. CustomField{Format: "INTERVAL '1 day'"}.
+func GenerateSeriesStep(start, stop, step interface{}) FunctionTable {
+	return Function("generate_series", seriesArg(start), seriesArg(stop), seriesArg(step))
+}
+
+// Unnest returns a new FunctionTable calling unnest on one or more arrays.
+// The arrays are expanded in parallel, producing one column per array. Go
+// []int, []int64, []float64, []string, []bool and []time.Time slices are
+// passed in as a single typed array argument.
+func Unnest(arrays ...interface{}) FunctionTable {
+	args := make([]interface{}, len(arrays))
+	for i := range arrays {
+		args[i] = arrayArg(arrays[i])
+	}
+	return Function("unnest", args...)
+}
+
+// JSONBToRecordset returns a new FunctionTable calling jsonb_to_recordset on
+// the JSON array. Its column definition list must be provided with As e.g.
+// JSONBToRecordset(data).As("x", "uid INT", "name TEXT").
+func JSONBToRecordset(json interface{}) FunctionTable {
+	return Function("jsonb_to_recordset", jsonbArg("jsonb_to_recordset", json))
+}
+
+// JSONBArrayElements returns a new FunctionTable calling jsonb_array_elements
+// on the JSON array, which produces a single jsonb column called value.
+func JSONBArrayElements(json interface{}) FunctionTable {
+	return Function("jsonb_array_elements", jsonbArg("jsonb_array_elements", json))
+}
+
+// WithOrdinality returns a new FunctionTable that numbers its output rows
+// starting from 1 in an extra bigint column, which should be named as the
+// last of the Columns.
+func (tbl FunctionTable) WithOrdinality() FunctionTable {
+	tbl.Ordinality = true
+	return tbl
+}
+
+// As returns a new FunctionTable with the alias and column names (or column
+// definitions).
+func (tbl FunctionTable) As(alias string, columns ...string) FunctionTable {
+	tbl.Alias = alias
+	tbl.Columns = columns
+	return tbl
+}
+
+// ToSQL marshals the FunctionTable into an SQL query and args.
+func (tbl FunctionTable) ToSQL() (string, []interface{}) {
+	if tbl.Function == "" {
+		return "", nil
+	}
+	queries, args := make([]string, len(tbl.Args)), []interface{}{}
+	for i := range tbl.Args {
+		if field, ok := tbl.Args[i].(Field); ok && field != nil {
+			query, subargs := field.ToSQL(nil)
+			queries[i] = query
+			args = append(args, subargs...)
+		} else {
+			queries[i] = "?"
+			args = append(args, tbl.Args[i])
+		}
+	}
+	query := tbl.Function + "(" + strings.Join(queries, ", ") + ")"
+	if tbl.Ordinality {
+		query = query + " WITH ORDINALITY"
+	}
+	return query, args
+}
+
+// GetAlias implements the Table interface. It returns the alias of the
+// FunctionTable.
+func (tbl FunctionTable) GetAlias() string {
+	return tbl.Alias
+}
+
+// GetName implements the Table interface. It returns the function name of
+// the FunctionTable.
+func (tbl FunctionTable) GetName() string {
+	return tbl.Function
+}
+
+// GetColumnAliases implements the ColumnAliaser interface. It returns the
+// column names (or column definitions) of the FunctionTable.
+func (tbl FunctionTable) GetColumnAliases() []string {
+	return tbl.Columns
+}
+
+// NumberField returns a NumberField referencing the column of the
+// FunctionTable.
+func (tbl FunctionTable) NumberField(column string) NumberField {
+	return NewNumberField(column, &TableInfo{Alias: tbl.Alias})
+}
+
+// StringField returns a StringField referencing the column of the
+// FunctionTable.
+func (tbl FunctionTable) StringField(column string) StringField {
+	return NewStringField(column, &TableInfo{Alias: tbl.Alias})
+}
+
+// TimeField returns a TimeField referencing the column of the FunctionTable.
+func (tbl FunctionTable) TimeField(column string) TimeField {
+	return NewTimeField(column, &TableInfo{Alias: tbl.Alias})
+}
+
+// BooleanField returns a BooleanField referencing the column of the
+// FunctionTable.
+func (tbl FunctionTable) BooleanField(column string) BooleanField {
+	return NewBooleanField(column, &TableInfo{Alias: tbl.Alias})
+}
+
+// JSONField returns a JSONField referencing the column of the FunctionTable.
+func (tbl FunctionTable) JSONField(column string) JSONField {
+	return NewJSONField(column, &TableInfo{Alias: tbl.Alias})
+}
+
+// arrayArg converts a Go slice into a single typed array argument, so that
+// postgres can resolve the type of polymorphic functions like unnest.
+func arrayArg(value interface{}) interface{} {
+	switch value := value.(type) {
+	case []int:
+		array := make(pq.Int64Array, len(value))
+		for i := range value {
+			array[i] = int64(value[i])
+		}
+		return CustomField{Format: "?::BIGINT[]", Values: []interface{}{array}}
+	case []int64:
+		return CustomField{Format: "?::BIGINT[]", Values: []interface{}{pq.Int64Array(value)}}
+	case []float64:
+		return CustomField{Format: "?::FLOAT[]", Values: []interface{}{pq.Float64Array(value)}}
+	case []string:
+		return CustomField{Format: "?::TEXT[]", Values: []interface{}{pq.StringArray(value)}}
+	case []bool:
+		return CustomField{Format: "?::BOOLEAN[]", Values: []interface{}{pq.BoolArray(value)}}
+	case []time.Time:
+		return CustomField{Format: "?::TIMESTAMPTZ[]", Values: []interface{}{pq.GenericArray{A: value}}}
+	}
+	return value
+}
+
+// seriesArg casts a Go int, float or time.Time into a typed argument for
+// generate_series. Fields and everything else are returned as is.
+func seriesArg(value interface{}) interface{} {
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return CustomField{Format: "?::BIGINT", Values: []interface{}{value}}
+	case float32, float64:
+		return CustomField{Format: "?::NUMERIC", Values: []interface{}{value}}
+	case time.Time:
+		return CustomField{Format: "?::TIMESTAMPTZ", Values: []interface{}{value}}
+	}
+	return value
+}
+
+// jsonbArg casts a JSON string or byte slice to jsonb. Maps, structs and
+// slices are marshalled into JSON first, and Fields are returned as is. If the
+// value cannot be marshalled, a ConvertError is passed in its place.
+func jsonbArg(function string, value interface{}) interface{} {
+	switch value := value.(type) {
+	case Field:
+		return value
+	case []byte:
+		return CustomField{Format: "?::JSONB", Values: []interface{}{string(value)}}
+	}
+	value, err := ConvertValue(JSONField{}, value)
+	if err != nil {
+		value = ConvertError{Name: function, Err: err}
+	}
+	return CustomField{Format: "?::JSONB", Values: []interface{}{value}}
+}
@@ -50,10 +50,20 @@ func (joins JoinGroups) WriteSQL(buf *strings.Builder, args *[]interface{}) (wri
 		}
 		if joins[i].Table.GetAlias() != "" {
 			buf.WriteString(string(joins[i].JoinType) + " " + tableQuery + " AS " + joins[i].Table.GetAlias())
+			if columnAliases := ColumnAliasSQL(joins[i].Table); columnAliases != "" {
+				buf.WriteString(" " + columnAliases)
+			}
 		} else {
 			buf.WriteString(string(joins[i].JoinType) + " " + tableQuery)
 		}
 		*args = append(*args, tableArgs...)
+		if sample, ok := joins[i].Table.(TableSample); ok {
+			sampleQuery, sampleArgs := sample.SampleSQL()
+			if sampleQuery != "" {
+				buf.WriteString(" " + sampleQuery)
+				*args = append(*args, sampleArgs...)
+			}
+		}
 		written = true
 		joins[i].OnPredicates.Toplevel = true
 		joins[i].OnPredicates.WriteSQL(buf, args, "ON ", "", nil)
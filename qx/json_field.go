@@ -4,8 +4,8 @@ import (
 	"encoding/json"
 )
 
-// JSONField either represents a JSON column or a literal value that can be
-// marshalled into a JSON string.
+// JSONField either represents a JSON column, a JSON expression or a literal
+// value that can be marshalled into a JSON string.
 type JSONField struct {
 	// JSONField will be one of the following:
 
@@ -18,6 +18,13 @@ type JSONField struct {
 	name       string
 	descending *bool
 	nullsfirst *bool
+
+	// 3) JSON expression
+	// Examples of JSON expressions:
+	// | query                            | args |
+	// |----------------------------------|------|
+	// | (SELECT u.x FROM users AS u ...) |      |
+	expression Field
 }
 
 // ToSQL marshals a JSONField into an SQL query and args (as described in the
@@ -25,6 +32,16 @@ type JSONField struct {
 // in the excludeTableQualifiers list, the output column name will not be table
 // qualified.
 func (f JSONField) ToSQL(excludeTableQualifiers []string) (string, []interface{}) {
+	// 3) JSON expression
+	if f.expression != nil {
+		return CustomField{
+			Format:       "?",
+			Values:       []interface{}{f.expression},
+			IsDesc:       f.descending,
+			IsNullsFirst: f.nullsfirst,
+		}.ToSQL(excludeTableQualifiers)
+	}
+
 	// 1) Literal JSONable value
 	if f.value != nil {
 		return "?", []interface{}{f.value}
@@ -73,6 +90,15 @@ func NewJSONField(name string, tbl *TableInfo) JSONField {
 	return f
 }
 
+// JSONExpression returns a new JSONField representing an arbitrary Field (such
+// as a scalar Subquery), so that it can be used wherever a JSONField is
+// expected.
+func JSONExpression(field Field) JSONField {
+	return JSONField{
+		expression: field,
+	}
+}
+
 // JSON returns a new JSONField representing a literal JSONable value. It
 // returns an error indicating if the value can be marshalled into JSON.
 func JSON(val interface{}) (JSONField, error) {
@@ -118,6 +144,13 @@ func (f JSONField) As(alias string) JSONField {
 	return f
 }
 
+// Excluded returns a new JSONField that references the row proposed for
+// insertion in an INSERT ... ON CONFLICT DO UPDATE query i.e. 'EXCLUDED.field'.
+func (f JSONField) Excluded() JSONField {
+	f.table = &TableInfo{Alias: "EXCLUDED"}
+	return f
+}
+
 // Asc returns a new JSONField indicating that it should be ordered in
 // ascending order i.e. 'ORDER BY field ASC'.
 func (f JSONField) Asc() JSONField {
@@ -166,6 +199,26 @@ func (f JSONField) IsNotNull() Predicate {
 	}
 }
 
+// IsDistinctFrom returns an 'A IS DISTINCT FROM B' Predicate. It only accepts
+// JSONField.
+func (f JSONField) IsDistinctFrom(field JSONField) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsDistinctFrom,
+		LeftField:  f,
+		RightField: field,
+	}
+}
+
+// IsNotDistinctFrom returns an 'A IS NOT DISTINCT FROM B' Predicate. It only
+// accepts JSONField.
+func (f JSONField) IsNotDistinctFrom(field JSONField) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsNotDistinctFrom,
+		LeftField:  f,
+		RightField: field,
+	}
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of a JSONField.
 func (f JSONField) String() string {
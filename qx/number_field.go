@@ -1,5 +1,9 @@
 package qx
 
+import (
+	"github.com/lib/pq"
+)
+
 // NumberField either represents a number column, a number expression or a
 // literal number value.
 type NumberField struct {
@@ -49,9 +53,11 @@ func (f NumberField) ToSQL(excludeTableQualifiers []string) (string, []interface
 			values[i] = f.fields[i]
 		}
 		return CustomField{
-			Alias:  f.alias,
-			Format: *f.format,
-			Values: values,
+			Alias:        f.alias,
+			Format:       *f.format,
+			Values:       values,
+			IsDesc:       f.descending,
+			IsNullsFirst: f.nullsfirst,
 		}.ToSQL(excludeTableQualifiers)
 	}
 
@@ -117,6 +123,17 @@ func Int64(num int64) NumberField {
 	}
 }
 
+// NumberExpression returns a new NumberField representing an arbitrary Field
+// (such as a scalar Subquery), so that it can be used wherever a NumberField is
+// expected.
+func NumberExpression(field Field) NumberField {
+	format := "?"
+	return NumberField{
+		format: &format,
+		fields: []Field{field},
+	}
+}
+
 // Float64 returns a new NumberField representing a literal float64 value.
 func Float64(num float64) NumberField {
 	return NumberField{
@@ -166,6 +183,13 @@ func (f NumberField) As(alias string) NumberField {
 	return f
 }
 
+// Excluded returns a new NumberField that references the row proposed for
+// insertion in an INSERT ... ON CONFLICT DO UPDATE query i.e. 'EXCLUDED.field'.
+func (f NumberField) Excluded() NumberField {
+	f.table = &TableInfo{Alias: "EXCLUDED"}
+	return f
+}
+
 // Asc returns a new NumberField indicating that it should be ordered in
 // ascending order i.e. 'ORDER BY field ASC'.
 func (f NumberField) Asc() NumberField {
@@ -376,6 +400,266 @@ func (f NumberField) LeInt(num int) Predicate {
 	}
 }
 
+// InInt returns an 'A IN (B1, B2, B3...)' Predicate. It only accepts []int.
+func (f NumberField) InInt(nums []int) InPredicate {
+	values, array := make([]interface{}, len(nums)), make(pq.Int64Array, len(nums))
+	for i := range nums {
+		values[i] = nums[i]
+		array[i] = int64(nums[i])
+	}
+	return InPredicate{
+		Field:     f,
+		Values:    values,
+		Array:     array,
+		ArrayType: "BIGINT",
+	}
+}
+
+// InInt64 returns an 'A IN (B1, B2, B3...)' Predicate. It only accepts
+// []int64.
+func (f NumberField) InInt64(nums []int64) InPredicate {
+	values := make([]interface{}, len(nums))
+	for i := range nums {
+		values[i] = nums[i]
+	}
+	return InPredicate{
+		Field:     f,
+		Values:    values,
+		Array:     pq.Int64Array(nums),
+		ArrayType: "BIGINT",
+	}
+}
+
+// InFloat64 returns an 'A IN (B1, B2, B3...)' Predicate. It only accepts
+// []float64.
+func (f NumberField) InFloat64(nums []float64) InPredicate {
+	values := make([]interface{}, len(nums))
+	for i := range nums {
+		values[i] = nums[i]
+	}
+	return InPredicate{
+		Field:     f,
+		Values:    values,
+		Array:     pq.Float64Array(nums),
+		ArrayType: "FLOAT",
+	}
+}
+
+// NotInInt returns an 'A NOT IN (B1, B2, B3...)' Predicate. It only accepts
+// []int.
+func (f NumberField) NotInInt(nums []int) InPredicate {
+	p := f.InInt(nums)
+	p.Negative = true
+	return p
+}
+
+// NotInInt64 returns an 'A NOT IN (B1, B2, B3...)' Predicate. It only accepts
+// []int64.
+func (f NumberField) NotInInt64(nums []int64) InPredicate {
+	p := f.InInt64(nums)
+	p.Negative = true
+	return p
+}
+
+// NotInFloat64 returns an 'A NOT IN (B1, B2, B3...)' Predicate. It only
+// accepts []float64.
+func (f NumberField) NotInFloat64(nums []float64) InPredicate {
+	p := f.InFloat64(nums)
+	p.Negative = true
+	return p
+}
+
+// IsDistinctFrom returns an 'A IS DISTINCT FROM B' Predicate. It only accepts
+// NumberField.
+func (f NumberField) IsDistinctFrom(field NumberField) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsDistinctFrom,
+		LeftField:  f,
+		RightField: field,
+	}
+}
+
+// IsDistinctFromInt returns an 'A IS DISTINCT FROM B' Predicate. It only
+// accepts int.
+func (f NumberField) IsDistinctFromInt(num int) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsDistinctFrom,
+		LeftField:  f,
+		RightField: Int(num),
+	}
+}
+
+// IsDistinctFromFloat64 returns an 'A IS DISTINCT FROM B' Predicate. It only
+// accepts float64.
+func (f NumberField) IsDistinctFromFloat64(num float64) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsDistinctFrom,
+		LeftField:  f,
+		RightField: Float64(num),
+	}
+}
+
+// IsNotDistinctFrom returns an 'A IS NOT DISTINCT FROM B' Predicate. It only
+// accepts NumberField.
+func (f NumberField) IsNotDistinctFrom(field NumberField) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsNotDistinctFrom,
+		LeftField:  f,
+		RightField: field,
+	}
+}
+
+// IsNotDistinctFromInt returns an 'A IS NOT DISTINCT FROM B' Predicate. It only
+// accepts int.
+func (f NumberField) IsNotDistinctFromInt(num int) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsNotDistinctFrom,
+		LeftField:  f,
+		RightField: Int(num),
+	}
+}
+
+// IsNotDistinctFromFloat64 returns an 'A IS NOT DISTINCT FROM B' Predicate. It
+// only accepts float64.
+func (f NumberField) IsNotDistinctFromFloat64(num float64) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsNotDistinctFrom,
+		LeftField:  f,
+		RightField: Float64(num),
+	}
+}
+
+// Between returns an 'A BETWEEN X AND Y' Predicate. It only accepts
+// NumberField.
+func (f NumberField) Between(start, end NumberField) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateBetween,
+		Field:    f,
+		FieldX:   start,
+		FieldY:   end,
+	}
+}
+
+// BetweenInt returns an 'A BETWEEN X AND Y' Predicate. It only accepts int.
+func (f NumberField) BetweenInt(start, end int) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateBetween,
+		Field:    f,
+		FieldX:   Int(start),
+		FieldY:   Int(end),
+	}
+}
+
+// BetweenFloat64 returns an 'A BETWEEN X AND Y' Predicate. It only accepts
+// float64.
+func (f NumberField) BetweenFloat64(start, end float64) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateBetween,
+		Field:    f,
+		FieldX:   Float64(start),
+		FieldY:   Float64(end),
+	}
+}
+
+// NotBetween returns an 'A NOT BETWEEN X AND Y' Predicate. It only accepts
+// NumberField.
+func (f NumberField) NotBetween(start, end NumberField) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateNotBetween,
+		Field:    f,
+		FieldX:   start,
+		FieldY:   end,
+	}
+}
+
+// NotBetweenInt returns an 'A NOT BETWEEN X AND Y' Predicate. It only accepts
+// int.
+func (f NumberField) NotBetweenInt(start, end int) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateNotBetween,
+		Field:    f,
+		FieldX:   Int(start),
+		FieldY:   Int(end),
+	}
+}
+
+// NotBetweenFloat64 returns an 'A NOT BETWEEN X AND Y' Predicate. It only
+// accepts float64.
+func (f NumberField) NotBetweenFloat64(start, end float64) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateNotBetween,
+		Field:    f,
+		FieldX:   Float64(start),
+		FieldY:   Float64(end),
+	}
+}
+
+// BetweenSymmetric returns an 'A BETWEEN SYMMETRIC X AND Y' Predicate. It only
+// accepts NumberField.
+func (f NumberField) BetweenSymmetric(start, end NumberField) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateBetweenSymmetric,
+		Field:    f,
+		FieldX:   start,
+		FieldY:   end,
+	}
+}
+
+// BetweenSymmetricInt returns an 'A BETWEEN SYMMETRIC X AND Y' Predicate. It
+// only accepts int.
+func (f NumberField) BetweenSymmetricInt(start, end int) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateBetweenSymmetric,
+		Field:    f,
+		FieldX:   Int(start),
+		FieldY:   Int(end),
+	}
+}
+
+// BetweenSymmetricFloat64 returns an 'A BETWEEN SYMMETRIC X AND Y' Predicate.
+// It only accepts float64.
+func (f NumberField) BetweenSymmetricFloat64(start, end float64) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateBetweenSymmetric,
+		Field:    f,
+		FieldX:   Float64(start),
+		FieldY:   Float64(end),
+	}
+}
+
+// NotBetweenSymmetric returns an 'A NOT BETWEEN SYMMETRIC X AND Y' Predicate.
+// It only accepts NumberField.
+func (f NumberField) NotBetweenSymmetric(start, end NumberField) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateNotBetweenSymmetric,
+		Field:    f,
+		FieldX:   start,
+		FieldY:   end,
+	}
+}
+
+// NotBetweenSymmetricInt returns an 'A NOT BETWEEN SYMMETRIC X AND Y'
+// Predicate. It only accepts int.
+func (f NumberField) NotBetweenSymmetricInt(start, end int) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateNotBetweenSymmetric,
+		Field:    f,
+		FieldX:   Int(start),
+		FieldY:   Int(end),
+	}
+}
+
+// NotBetweenSymmetricFloat64 returns an 'A NOT BETWEEN SYMMETRIC X AND Y'
+// Predicate. It only accepts float64.
+func (f NumberField) NotBetweenSymmetricFloat64(start, end float64) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateNotBetweenSymmetric,
+		Field:    f,
+		FieldX:   Float64(start),
+		FieldY:   Float64(end),
+	}
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of a NumberField.
 func (f NumberField) String() string {
@@ -0,0 +1,140 @@
+package qx
+
+import (
+	"strconv"
+)
+
+// Ordering is a Field representing a single term in the ORDER BY clause i.e.
+// 'expression [ASC | DESC | USING operator] [NULLS { FIRST | LAST }]'. The
+// expression is either a Field, a Predicate (e.g. ORDER BY status = 'accepted'
+// DESC), a select-list position or an output column name.
+//
+// An Ordering without any direction is rendered as just its expression, which
+// means that positions and output column names can also be used in SELECT
+// DISTINCT ON to keep it consistent with the ORDER BY clause.
+type Ordering struct {
+	Field         Field
+	Predicate     Predicate
+	UsingOperator string
+	IsDesc        *bool
+	IsNullsFirst  *bool
+}
+
+// ToSQL marshals an Ordering into an SQL query and args as described in the
+// Ordering struct description.
+func (o Ordering) ToSQL(excludeTableQualifiers []string) (string, []interface{}) {
+	var query string
+	var args []interface{}
+	switch {
+	case o.Predicate != nil:
+		query, args = o.Predicate.ToSQL(excludeTableQualifiers)
+		if query == "" {
+			return "", nil
+		}
+		query = "(" + query + ")"
+	case o.Field != nil:
+		query, args = o.Field.ToSQL(excludeTableQualifiers)
+		if query == "" {
+			return "", nil
+		}
+	default:
+		return "", nil
+	}
+	if o.UsingOperator != "" {
+		query = query + " USING " + o.UsingOperator
+	} else if o.IsDesc != nil {
+		if *o.IsDesc {
+			query = query + " DESC"
+		} else {
+			query = query + " ASC"
+		}
+	}
+	if o.IsNullsFirst != nil {
+		if *o.IsNullsFirst {
+			query = query + " NULLS FIRST"
+		} else {
+			query = query + " NULLS LAST"
+		}
+	}
+	return query, args
+}
+
+// OrderByPredicate returns a new Ordering that orders by the result of a
+// Predicate i.e. 'ORDER BY (predicate)'.
+func OrderByPredicate(predicate Predicate) Ordering {
+	return Ordering{Predicate: predicate}
+}
+
+// OrderByUsing returns a new Ordering that orders a Field using a specific
+// operator i.e. 'ORDER BY field USING operator'.
+func OrderByUsing(field Field, operator string) Ordering {
+	return Ordering{Field: field, UsingOperator: operator}
+}
+
+// Position returns a new Ordering that references a select-list item by its
+// (1-based) position i.e. 'ORDER BY 1'. The position is written literally into
+// the query as a placeholder would be treated as a constant.
+func Position(position int) Ordering {
+	return Ordering{Field: FieldLiteral(strconv.Itoa(position))}
+}
+
+// OutputName returns a new Ordering that references a select-list item by its
+// output column name or alias i.e. 'ORDER BY name'.
+func OutputName(name string) Ordering {
+	return Ordering{Field: FieldLiteral(name)}
+}
+
+// Asc returns a new Ordering indicating that it should be ordered in ascending
+// order i.e. 'ORDER BY field ASC'.
+func (o Ordering) Asc() Ordering {
+	isDesc := false
+	o.IsDesc = &isDesc
+	o.UsingOperator = ""
+	return o
+}
+
+// Desc returns a new Ordering indicating that it should be ordered in
+// descending order i.e. 'ORDER BY field DESC'.
+func (o Ordering) Desc() Ordering {
+	isDesc := true
+	o.IsDesc = &isDesc
+	o.UsingOperator = ""
+	return o
+}
+
+// Using returns a new Ordering indicating that it should be ordered using a
+// specific operator i.e. 'ORDER BY field USING operator'.
+func (o Ordering) Using(operator string) Ordering {
+	o.UsingOperator = operator
+	o.IsDesc = nil
+	return o
+}
+
+// NullsFirst returns a new Ordering indicating that it should be ordered with
+// nulls first i.e. 'ORDER BY field NULLS FIRST'.
+func (o Ordering) NullsFirst() Ordering {
+	isNullsFirst := true
+	o.IsNullsFirst = &isNullsFirst
+	return o
+}
+
+// NullsLast returns a new Ordering indicating that it should be ordered with
+// nulls last i.e. 'ORDER BY field NULLS LAST'.
+func (o Ordering) NullsLast() Ordering {
+	isNullsFirst := false
+	o.IsNullsFirst = &isNullsFirst
+	return o
+}
+
+// GetAlias implements the Field interface. It always returns an empty string
+// because Orderings do not have aliases.
+func (o Ordering) GetAlias() string {
+	return ""
+}
+
+// GetName implements the Field interface. It returns the name of the
+// Ordering.
+func (o Ordering) GetName() string {
+	name, _ := o.ToSQL(nil)
+	return name
+}
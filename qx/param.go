@@ -0,0 +1,153 @@
+package qx
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Parameter is a named placeholder that stands in for a value that is only
+// known when the query is executed. It is a Field, so it can be used anywhere
+// a literal value is accepted e.g. u.DISPLAYNAME.Set(qx.Param("name")). The
+// query is rendered with a placeholder in its place, and the value is bound
+// into the args with BindParams. This allows a query to be built once and
+// executed many times with different values.
+type Parameter struct {
+	Name string
+}
+
+// Param returns a new Parameter with the name.
+func Param(name string) Parameter {
+	return Parameter{Name: name}
+}
+
+// NumberParam returns a NumberField representing a Parameter, so that it can
+// be used wherever a NumberField is expected e.g. u.UID.Eq(qx.NumberParam("uid")).
+func NumberParam(name string) NumberField {
+	return NumberExpression(Param(name))
+}
+
+// StringParam returns a StringField representing a Parameter.
+func StringParam(name string) StringField {
+	return StringExpression(Param(name))
+}
+
+// TimeParam returns a TimeField representing a Parameter.
+func TimeParam(name string) TimeField {
+	return TimeExpression(Param(name))
+}
+
+// BooleanParam returns a BooleanField representing a Parameter.
+func BooleanParam(name string) BooleanField {
+	return BooleanExpression(Param(name))
+}
+
+// JSONParam returns a JSONField representing a Parameter.
+func JSONParam(name string) JSONField {
+	return JSONExpression(Param(name))
+}
+
+// ToSQL marshals the Parameter into a placeholder. The Parameter itself is
+// returned as the arg, to be replaced by BindParams.
+func (p Parameter) ToSQL([]string) (string, []interface{}) {
+	return "?", []interface{}{p}
+}
+
+// GetAlias implements the Field interface. It always returns an empty string.
+func (p Parameter) GetAlias() string {
+	return ""
+}
+
+// GetName implements the Field interface. It always returns an empty string
+// because the name of a Parameter is not a column name.
+func (p Parameter) GetName() string {
+	return ""
+}
+
+// String implements the fmt.Stringer interface.
+func (p Parameter) String() string {
+	return ":" + p.Name
+}
+
+// Value implements the driver.Valuer interface. It always returns an error,
+// since reaching the driver means the Parameter was never bound.
+func (p Parameter) Value() (driver.Value, error) {
+	return nil, fmt.Errorf("parameter %s was not bound", p.Name)
+}
+
+// BindParams returns a copy of the args where every Parameter is replaced by
+// its value. The values are either a map[string]interface{} keyed by the
+// Parameter names, or a struct (or pointer to a struct) whose fields are
+// matched by their `db` tag or, failing that, their name. It returns an error
+// if a Parameter has no value.
+func BindParams(args []interface{}, values interface{}) ([]interface{}, error) {
+	var hasParams bool
+	for _, arg := range args {
+		if _, ok := arg.(Parameter); ok {
+			hasParams = true
+			break
+		}
+	}
+	if !hasParams {
+		return args, nil
+	}
+	lookup, err := paramLookup(values)
+	if err != nil {
+		return nil, err
+	}
+	bound := make([]interface{}, len(args))
+	for i, arg := range args {
+		param, ok := arg.(Parameter)
+		if !ok {
+			bound[i] = arg
+			continue
+		}
+		value, ok := lookup(param.Name)
+		if !ok {
+			return nil, fmt.Errorf("no value bound to parameter %s", param.Name)
+		}
+		bound[i] = value
+	}
+	return bound, nil
+}
+
+// paramLookup returns a function that looks up a parameter value by name in
+// the values passed to BindParams.
+func paramLookup(values interface{}) (func(name string) (interface{}, bool), error) {
+	switch v := values.(type) {
+	case nil:
+		return func(string) (interface{}, bool) { return nil, false }, nil
+	case map[string]interface{}:
+		return func(name string) (interface{}, bool) {
+			value, ok := v[name]
+			return value, ok
+		}, nil
+	}
+	rv := reflect.ValueOf(values)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("parameter values must be a map[string]interface{} or a struct, got %T", values)
+	}
+	fields := make(map[string]int)
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		name := field.Name
+		if tag := strings.Split(field.Tag.Get("db"), ",")[0]; tag != "" {
+			name = tag
+		}
+		fields[name] = i
+	}
+	return func(name string) (interface{}, bool) {
+		i, ok := fields[name]
+		if !ok {
+			return nil, false
+		}
+		return rv.Field(i).Interface(), true
+	}, nil
+}
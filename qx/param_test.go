@@ -0,0 +1,52 @@
+package qx
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestBindParams(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		values      interface{}
+		wantArgs    []interface{}
+		wantErr     bool
+	}
+	type User struct {
+		UID   int
+		Email string `db:"email"`
+	}
+	args := []interface{}{Param("UID"), 5, Param("email")}
+	tests := []TT{
+		{"map", map[string]interface{}{"UID": 1, "email": "a@b.c"}, []interface{}{1, 5, "a@b.c"}, false},
+		{"struct", User{UID: 2, Email: "d@e.f"}, []interface{}{2, 5, "d@e.f"}, false},
+		{"pointer to struct", &User{UID: 3}, []interface{}{3, 5, ""}, false},
+		{"missing value", map[string]interface{}{"UID": 1}, nil, true},
+		{"no values", nil, nil, true},
+		{"unsupported values", 1, nil, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotArgs, err := BindParams(args, tt.values)
+			if tt.wantErr {
+				is.True(err != nil)
+				return
+			}
+			is.NoErr(err)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+	is := is.New(t)
+	gotArgs, err := BindParams([]interface{}{1, "a"}, nil) // nothing to bind
+	is.NoErr(err)
+	is.Equal([]interface{}{1, "a"}, gotArgs)
+	u := USERS().As("u")
+	gotQuery, gotArgs := u.UID.Eq(NumberParam("uid")).ToSQL(nil)
+	is.Equal("u.uid = ?", gotQuery)
+	is.Equal([]interface{}{Param("uid")}, gotArgs)
+	is.Equal("u.uid = :uid", MySQLInterpolateSQL(gotQuery, gotArgs...))
+}
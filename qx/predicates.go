@@ -101,6 +101,43 @@ func Or(predicates ...Predicate) VariadicPredicate {
 	}
 }
 
+// NotPredicate represents the 'NOT X' SQL construct. X is enclosed in brackets
+// unless it is a plain boolean column, so that composite predicates like
+// 'NOT (A AND B)' and boolean expressions are negated as a whole.
+type NotPredicate struct {
+	Predicate Predicate
+}
+
+// ToSQL marshals a NotPredicate into an SQL query and args as described in the
+// NotPredicate struct description. If the child Predicate evaluates to an
+// empty string, NotPredicate also evaluates to an empty string.
+func (p NotPredicate) ToSQL(excludeTableQualifiers []string) (string, []interface{}) {
+	if p.Predicate == nil {
+		return "", nil
+	}
+	if pred, ok := p.Predicate.(VariadicPredicate); ok {
+		// We are already going to bracket the VariadicPredicate ourselves
+		pred.Toplevel = true
+		p.Predicate = pred
+	}
+	query, args := p.Predicate.ToSQL(excludeTableQualifiers)
+	if query == "" {
+		return "", nil
+	}
+	if f, ok := p.Predicate.(BooleanField); ok && f.expression == nil && f.value == nil {
+		return "NOT " + query, args
+	}
+	return "NOT (" + query + ")", args
+}
+
+// AssertPredicate implements the Predicate interface.
+func (p NotPredicate) AssertPredicate() {}
+
+// Not returns a 'NOT X' Predicate that negates any arbitrary Predicate.
+func Not(predicate Predicate) NotPredicate {
+	return NotPredicate{Predicate: predicate}
+}
+
 // WriteSQL will write the VariadicPredicate into the buffer and args as
 // described in the VariadicPredicate struct description. The result is
 // prepended and appended with the prependwith and appendwith arguments. The
@@ -132,11 +169,18 @@ func (p VariadicPredicate) WriteSQL(buf *strings.Builder, args *[]interface{}, p
 type UnaryPredicateOperator string
 
 const (
-	PredicateIsNull    UnaryPredicateOperator = "IS NULL"
-	PredicateIsNotNull UnaryPredicateOperator = "IS NOT NULL"
+	PredicateIsNull       UnaryPredicateOperator = "IS NULL"
+	PredicateIsNotNull    UnaryPredicateOperator = "IS NOT NULL"
+	PredicateIsTrue       UnaryPredicateOperator = "IS TRUE"
+	PredicateIsNotTrue    UnaryPredicateOperator = "IS NOT TRUE"
+	PredicateIsFalse      UnaryPredicateOperator = "IS FALSE"
+	PredicateIsNotFalse   UnaryPredicateOperator = "IS NOT FALSE"
+	PredicateIsUnknown    UnaryPredicateOperator = "IS UNKNOWN"
+	PredicateIsNotUnknown UnaryPredicateOperator = "IS NOT UNKNOWN"
 )
 
-// UnaryPredicate represents the 'X [IS NULL | IS NOT NULL]' SQL construct.
+// UnaryPredicate represents the 'X [IS NULL | IS NOT NULL | IS TRUE | IS
+// FALSE...]' SQL construct.
 type UnaryPredicate struct {
 	Operator UnaryPredicateOperator
 	Field    Field
@@ -151,10 +195,11 @@ func (p UnaryPredicate) ToSQL(excludeTableQualifiers []string) (string, []interf
 	}
 	query, args := p.Field.ToSQL(excludeTableQualifiers)
 	switch p.Operator {
-	case PredicateIsNull:
-		return query + " IS NULL", args
-	case PredicateIsNotNull:
-		return query + " IS NOT NULL", args
+	case PredicateIsNull, PredicateIsNotNull,
+		PredicateIsTrue, PredicateIsNotTrue,
+		PredicateIsFalse, PredicateIsNotFalse,
+		PredicateIsUnknown, PredicateIsNotUnknown:
+		return query + " " + string(p.Operator), args
 	default:
 		return "", nil
 	}
@@ -259,3 +304,92 @@ func (p TernaryPredicate) AssertPredicate() {}
 func (p TernaryPredicate) String() string {
 	return fmt.Sprint(p.ToSQL(nil))
 }
+
+// DefaultInArray determines whether In/NotIn Predicates are rendered as a
+// single array parameter i.e. 'A = ANY(?::type[])' instead of a list of
+// parameters i.e. 'A IN (?, ?, ?...)'. It can be overridden on a per
+// predicate basis with (InPredicate).AsArray and (InPredicate).AsList.
+//
+// The array form always produces the same SQL string regardless of how many
+// values are in the list, which is friendlier to statement caching and is not
+// bound by the database's parameter limit.
+var DefaultInArray = false
+
+// InPredicate represents the 'A [NOT] IN (B1, B2, B3...)' SQL construct, or
+// its array equivalent 'A = ANY(?::type[])' / 'A <> ALL(?::type[])'.
+//
+// An empty list of values evaluates to FALSE for IN and TRUE for NOT IN.
+type InPredicate struct {
+	Negative bool
+	Field    Field
+	// Values is the list of values used when rendering the IN list.
+	Values []interface{}
+	// Array is the single array argument (e.g. pq.Int64Array) used when
+	// rendering the '= ANY(?::type[])' form.
+	Array     interface{}
+	ArrayType string
+	// UseArray overrides DefaultInArray if it is not nil.
+	UseArray *bool
+}
+
+// ToSQL marshals an InPredicate into an SQL query and args as described in the
+// InPredicate struct description.
+func (p InPredicate) ToSQL(excludeTableQualifiers []string) (string, []interface{}) {
+	if len(p.Values) == 0 {
+		if p.Negative {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	}
+	if p.Field == nil {
+		p.Field = _NULL
+	}
+	query, args := p.Field.ToSQL(excludeTableQualifiers)
+	useArray := DefaultInArray
+	if p.UseArray != nil {
+		useArray = *p.UseArray
+	}
+	if useArray && p.Array != nil {
+		cast := ""
+		if p.ArrayType != "" {
+			cast = "::" + p.ArrayType + "[]"
+		}
+		if p.Negative {
+			query = query + " <> ALL(?" + cast + ")"
+		} else {
+			query = query + " = ANY(?" + cast + ")"
+		}
+		args = append(args, p.Array)
+		return query, args
+	}
+	if p.Negative {
+		query = query + " NOT IN (?" + strings.Repeat(", ?", len(p.Values)-1) + ")"
+	} else {
+		query = query + " IN (?" + strings.Repeat(", ?", len(p.Values)-1) + ")"
+	}
+	args = append(args, p.Values...)
+	return query, args
+}
+
+// AssertPredicate implements the Predicate interface.
+func (p InPredicate) AssertPredicate() {}
+
+// AsArray returns a new InPredicate that is rendered as a single array
+// parameter i.e. 'A = ANY(?::type[])', regardless of DefaultInArray.
+func (p InPredicate) AsArray() InPredicate {
+	useArray := true
+	p.UseArray = &useArray
+	return p
+}
+
+// AsList returns a new InPredicate that is rendered as a list of parameters
+// i.e. 'A IN (?, ?, ?...)', regardless of DefaultInArray.
+func (p InPredicate) AsList() InPredicate {
+	useArray := false
+	p.UseArray = &useArray
+	return p
+}
+
+func (p InPredicate) String() string {
+	return fmt.Sprint(p.ToSQL(nil))
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/lib/pq"
 	"github.com/matryer/is"
 )
 
@@ -292,6 +293,13 @@ func TestUnaryPredicate_ToSQL(t *testing.T) {
 			wantQuery := "NULL IS NULL"
 			return TT{DESCRIPTION, p, nil, wantQuery, nil}
 		}(),
+		func() TT {
+			DESCRIPTION := "IS NOT TRUE"
+			tbl := NewTableInfo("public", "users")
+			p := NewBooleanField("is_user", tbl).IsNotTrue()
+			wantQuery := "users.is_user IS NOT TRUE"
+			return TT{DESCRIPTION, p.(UnaryPredicate), nil, wantQuery, nil}
+		}(),
 		func() TT {
 			DESCRIPTION := "excludeTableQualifiers is obeyed"
 			u := USERS().As("u")
@@ -355,6 +363,13 @@ func TestBinaryPredicate_ToSQL(t *testing.T) {
 			wantQuery := "NULL = email"
 			return TT{DESCRIPTION, p, excludeTableQualifiers, wantQuery, nil}
 		}(),
+		func() TT {
+			DESCRIPTION := "IS DISTINCT FROM"
+			u := USERS().As("u")
+			p := u.EMAIL.IsDistinctFromString("bob@email.com")
+			wantQuery := "u.email IS DISTINCT FROM ?"
+			return TT{DESCRIPTION, p.(BinaryPredicate), nil, wantQuery, []interface{}{"bob@email.com"}}
+		}(),
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -396,6 +411,94 @@ func TestTernaryPredicate_ToSQL(t *testing.T) {
 			wantQuery := "u.uid NOT BETWEEN cohort AND role"
 			return TT{DESCRIPTION, p, excludeTableQualifiers, wantQuery, nil}
 		}(),
+		func() TT {
+			DESCRIPTION := "NumberField BETWEEN SYMMETRIC"
+			u := USERS().As("u")
+			p := u.UID.BetweenSymmetricInt(10, 1)
+			wantQuery := "u.uid BETWEEN SYMMETRIC ? AND ?"
+			return TT{DESCRIPTION, p.(TernaryPredicate), nil, wantQuery, []interface{}{10, 1}}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.p.ToSQL(tt.excludeTableQualifiers)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestInPredicate_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION            string
+		p                      Predicate
+		excludeTableQualifiers []string
+		wantQuery              string
+		wantArgs               []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "IN list"
+			u := USERS().As("u")
+			p := u.UID.InInt([]int{1, 2, 3}).AsList()
+			wantQuery := "u.uid IN (?, ?, ?)"
+			return TT{DESCRIPTION, p, nil, wantQuery, []interface{}{1, 2, 3}}
+		}(),
+		func() TT {
+			DESCRIPTION := "NOT IN list"
+			u := USERS().As("u")
+			p := u.EMAIL.NotInString([]string{"a", "b"}).AsList()
+			wantQuery := "u.email NOT IN (?, ?)"
+			return TT{DESCRIPTION, p, nil, wantQuery, []interface{}{"a", "b"}}
+		}(),
+		func() TT {
+			DESCRIPTION := "IN array"
+			u := USERS().As("u")
+			p := u.UID.InInt([]int{1, 2, 3}).AsArray()
+			wantQuery := "u.uid = ANY(?::BIGINT[])"
+			return TT{DESCRIPTION, p, nil, wantQuery, []interface{}{pq.Int64Array{1, 2, 3}}}
+		}(),
+		func() TT {
+			DESCRIPTION := "NOT IN array"
+			u := USERS().As("u")
+			p := u.EMAIL.NotInString([]string{"a", "b"}).AsArray()
+			wantQuery := "u.email <> ALL(?::TEXT[])"
+			return TT{DESCRIPTION, p, nil, wantQuery, []interface{}{pq.StringArray{"a", "b"}}}
+		}(),
+		func() TT {
+			DESCRIPTION := "empty IN is always false"
+			u := USERS().As("u")
+			p := u.UID.InInt64(nil).AsArray()
+			return TT{DESCRIPTION, p, nil, "FALSE", nil}
+		}(),
+		func() TT {
+			DESCRIPTION := "empty NOT IN is always true"
+			u := USERS().As("u")
+			p := u.UID.NotInFloat64([]float64{})
+			return TT{DESCRIPTION, p, nil, "TRUE", nil}
+		}(),
+		func() TT {
+			DESCRIPTION := "empty NOT IN list is always true"
+			u := USERS().As("u")
+			p := u.UID.NotInInt([]int{}).AsList()
+			return TT{DESCRIPTION, p, nil, "TRUE", nil}
+		}(),
+		func() TT {
+			DESCRIPTION := "empty NOT IN array is always true"
+			u := USERS().As("u")
+			p := u.EMAIL.NotInString([]string{}).AsArray()
+			return TT{DESCRIPTION, p, nil, "TRUE", nil}
+		}(),
+		func() TT {
+			DESCRIPTION := "respect excludeTableQualifiers"
+			u := USERS().As("u")
+			p := u.UID.InInt([]int{5}).AsList()
+			excludeTableQualifiers := []string{u.GetAlias()}
+			return TT{DESCRIPTION, p, excludeTableQualifiers, "uid IN (?)", []interface{}{5}}
+		}(),
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -408,3 +511,64 @@ func TestTernaryPredicate_ToSQL(t *testing.T) {
 		})
 	}
 }
+
+func TestNotPredicate_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		p           NotPredicate
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "nil Predicate results in empty string"
+			return TT{DESCRIPTION, Not(nil), "", nil}
+		}(),
+		func() TT {
+			DESCRIPTION := "simple predicate is bracketed"
+			u := USERS().As("u")
+			p := Not(u.UID.EqInt(5))
+			return TT{DESCRIPTION, p, "NOT (u.uid = ?)", []interface{}{5}}
+		}(),
+		func() TT {
+			DESCRIPTION := "variadic predicate is bracketed only once"
+			u := USERS().As("u")
+			p := Not(Or(u.UID.EqInt(5), u.EMAIL.IsNull()))
+			return TT{DESCRIPTION, p, "NOT (u.uid = ? OR u.email IS NULL)", []interface{}{5}}
+		}(),
+		func() TT {
+			DESCRIPTION := "nested variadic predicates keep their own brackets"
+			u := USERS().As("u")
+			p := Not(And(Or(u.UID.EqInt(5), u.EMAIL.IsNull()), u.PASSWORD.IsNotNull()))
+			wantQuery := "NOT ((u.uid = ? OR u.email IS NULL) AND u.password IS NOT NULL)"
+			return TT{DESCRIPTION, p, wantQuery, []interface{}{5}}
+		}(),
+		func() TT {
+			DESCRIPTION := "BooleanField is not bracketed"
+			tbl := NewTableInfo("public", "users")
+			p := Not(NewBooleanField("is_user", tbl))
+			return TT{DESCRIPTION, p, "NOT users.is_user", nil}
+		}(),
+		func() TT {
+			DESCRIPTION := "BooleanExpression is bracketed"
+			u := USERS().As("u")
+			p := Not(BooleanExpression(CustomField{Format: "? OR ?", Values: []interface{}{u.UID.EqInt(1), u.UID.EqInt(2)}}))
+			return TT{DESCRIPTION, p, "NOT (u.uid = ? OR u.uid = ?)", []interface{}{1, 2}}
+		}(),
+		func() TT {
+			DESCRIPTION := "empty variadic predicate results in empty string"
+			p := Not(And())
+			return TT{DESCRIPTION, p, "", nil}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.p.ToSQL(nil)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
@@ -65,6 +65,18 @@ type QueryerContext interface {
 	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 }
 
+// Execer is an interface used to execute queries that do not return rows.
+type Execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// ExecerContext is an extension of the Execer interface, and can execute
+// queries with context.
+type ExecerContext interface {
+	Execer
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
 // Logger is an interface that provides logging.
 type Logger interface {
 	Println(v ...interface{})
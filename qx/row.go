@@ -22,6 +22,32 @@ func (r *QxRow) ScanInto(dest interface{}, field Field) {
 	}
 }
 
+// Values returns the values of the fields for the current row, in the same
+// order as the fields. Each value is whatever the database driver returns for
+// that column (e.g. int64, float64, string, []byte, time.Time or nil). It is
+// meant for iterating over columns by position, such as the columns of
+// TableInfo.Star.
+func (r *QxRow) Values(fields ...Field) []interface{} {
+	if !r.Active {
+		for _, field := range fields {
+			r.Fields = append(r.Fields, field)
+			r.Dest = append(r.Dest, new(interface{}))
+		}
+		return make([]interface{}, len(fields))
+	}
+	values := make([]interface{}, len(fields))
+	for i := range fields {
+		switch val := r.Dest[r.Index].(type) {
+		case *interface{}:
+			r.Index++
+			values[i] = *val
+		default:
+			panic("type mismatch")
+		}
+	}
+	return values
+}
+
 /* bool */
 
 func (r *QxRow) Bool(field BooleanField) bool {
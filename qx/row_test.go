@@ -0,0 +1,38 @@
+package qx
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestQxRow_Values(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	r := &QxRow{}
+	mapper := func() []interface{} {
+		return r.Values(u.Star()...)
+	}
+	mapper()
+	is.Equal(len(u.GetFields()), len(r.Fields))
+	is.Equal(r.Fields[0], u.DISPLAYNAME)
+	// simulate rows.Scan
+	for i := range r.Dest {
+		*(r.Dest[i].(*interface{})) = i
+	}
+	r.Active = true
+	is.Equal([]interface{}{0, 1, 2, 3}, mapper())
+}
+
+func TestTableInfo_Star(t *testing.T) {
+	is := is.New(t)
+	// a hand-written table that does not set TableInfo.Fields itself
+	tbl := NewTableInfo("public", "users")
+	tbl.Alias = "u"
+	uid := NewNumberField("uid", tbl)
+	email := NewStringField("email", tbl)
+	is.Equal(2, len(tbl.GetFields()))
+	is.Equal(Fields{uid, email}, tbl.Star())
+	query, _ := tbl.Star().ToSQL(nil)
+	is.Equal("u.uid, u.email", query)
+}
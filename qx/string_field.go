@@ -1,6 +1,13 @@
 package qx
 
-// StringField either represents a string column or a literal string value.
+import (
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// StringField either represents a string column, a string
+// expression or a literal string value.
 type StringField struct {
 	// StringField will be one of the following:
 
@@ -21,8 +28,16 @@ type StringField struct {
 	alias      string
 	table      *TableInfo
 	name       string
+	collation  *string
 	descending *bool
 	nullsfirst *bool
+
+	// 3) String expression
+	// Examples of string expressions:
+	// | query                            | args |
+	// |----------------------------------|------|
+	// | (SELECT u.x FROM users AS u ...) |      |
+	expression Field
 }
 
 // ToSQL marshals a StringField into an SQL query and args (as described in the
@@ -30,8 +45,25 @@ type StringField struct {
 // appears in the excludeTableQualifiers list, the output column name will not
 // be table qualified.
 func (f StringField) ToSQL(excludeTableQualifiers []string) (string, []interface{}) {
+	// 3) String expression
+	if f.expression != nil {
+		format := "?"
+		if f.collation != nil {
+			format = format + " COLLATE " + quoteCollation(*f.collation)
+		}
+		return CustomField{
+			Format:       format,
+			Values:       []interface{}{f.expression},
+			IsDesc:       f.descending,
+			IsNullsFirst: f.nullsfirst,
+		}.ToSQL(excludeTableQualifiers)
+	}
+
 	// 1) Literal string value
 	if f.value != nil {
+		if f.collation != nil {
+			return "? COLLATE " + quoteCollation(*f.collation), []interface{}{*f.value}
+		}
 		return "?", []interface{}{*f.value}
 	}
 
@@ -51,6 +83,9 @@ func (f StringField) ToSQL(excludeTableQualifiers []string) (string, []interface
 		}
 	}
 	columnName := tableQualifier + f.name
+	if f.collation != nil {
+		columnName = columnName + " COLLATE " + quoteCollation(*f.collation)
+	}
 	if f.descending != nil {
 		if *f.descending {
 			columnName = columnName + " DESC"
@@ -78,6 +113,15 @@ func NewStringField(name string, table *TableInfo) StringField {
 	return f
 }
 
+// StringExpression returns a new StringField representing an arbitrary Field
+// (such as a scalar Subquery), so that it can be used wherever a StringField is
+// expected.
+func StringExpression(field Field) StringField {
+	return StringField{
+		expression: field,
+	}
+}
+
 // String returns a new StringField representing a literal string value.
 func String(s string) StringField {
 	return StringField{
@@ -109,6 +153,20 @@ func (f StringField) As(alias string) StringField {
 	return f
 }
 
+// Collate returns a new StringField that uses the specified collation i.e.
+// 'field COLLATE "name"'. It is commonly used in the ORDER BY clause.
+func (f StringField) Collate(collation string) StringField {
+	f.collation = &collation
+	return f
+}
+
+// Excluded returns a new StringField that references the row proposed for
+// insertion in an INSERT ... ON CONFLICT DO UPDATE query i.e. 'EXCLUDED.field'.
+func (f StringField) Excluded() StringField {
+	f.table = &TableInfo{Alias: "EXCLUDED"}
+	return f
+}
+
 // Asc returns a new StringField indicating that it should be ordered in
 // ascending order i.e. 'ORDER BY field ASC'.
 func (f StringField) Asc() StringField {
@@ -301,6 +359,157 @@ func (f StringField) NotILikeString(s string) Predicate {
 	}
 }
 
+// InString returns an 'A IN (B1, B2, B3...)' Predicate. It only accepts
+// []string.
+func (f StringField) InString(strs []string) InPredicate {
+	values := make([]interface{}, len(strs))
+	for i := range strs {
+		values[i] = strs[i]
+	}
+	return InPredicate{
+		Field:     f,
+		Values:    values,
+		Array:     pq.StringArray(strs),
+		ArrayType: "TEXT",
+	}
+}
+
+// NotInString returns an 'A NOT IN (B1, B2, B3...)' Predicate. It only
+// accepts []string.
+func (f StringField) NotInString(strs []string) InPredicate {
+	p := f.InString(strs)
+	p.Negative = true
+	return p
+}
+
+// IsDistinctFrom returns an 'A IS DISTINCT FROM B' Predicate. It only accepts
+// StringField.
+func (f StringField) IsDistinctFrom(field StringField) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsDistinctFrom,
+		LeftField:  f,
+		RightField: field,
+	}
+}
+
+// IsDistinctFromString returns an 'A IS DISTINCT FROM B' Predicate. It only
+// accepts string.
+func (f StringField) IsDistinctFromString(s string) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsDistinctFrom,
+		LeftField:  f,
+		RightField: String(s),
+	}
+}
+
+// IsNotDistinctFrom returns an 'A IS NOT DISTINCT FROM B' Predicate. It only
+// accepts StringField.
+func (f StringField) IsNotDistinctFrom(field StringField) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsNotDistinctFrom,
+		LeftField:  f,
+		RightField: field,
+	}
+}
+
+// IsNotDistinctFromString returns an 'A IS NOT DISTINCT FROM B' Predicate. It
+// only accepts string.
+func (f StringField) IsNotDistinctFromString(s string) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsNotDistinctFrom,
+		LeftField:  f,
+		RightField: String(s),
+	}
+}
+
+// Between returns an 'A BETWEEN X AND Y' Predicate. It only accepts
+// StringField.
+func (f StringField) Between(start, end StringField) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateBetween,
+		Field:    f,
+		FieldX:   start,
+		FieldY:   end,
+	}
+}
+
+// BetweenString returns an 'A BETWEEN X AND Y' Predicate. It only accepts
+// string.
+func (f StringField) BetweenString(start, end string) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateBetween,
+		Field:    f,
+		FieldX:   String(start),
+		FieldY:   String(end),
+	}
+}
+
+// NotBetween returns an 'A NOT BETWEEN X AND Y' Predicate. It only accepts
+// StringField.
+func (f StringField) NotBetween(start, end StringField) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateNotBetween,
+		Field:    f,
+		FieldX:   start,
+		FieldY:   end,
+	}
+}
+
+// NotBetweenString returns an 'A NOT BETWEEN X AND Y' Predicate. It only
+// accepts string.
+func (f StringField) NotBetweenString(start, end string) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateNotBetween,
+		Field:    f,
+		FieldX:   String(start),
+		FieldY:   String(end),
+	}
+}
+
+// BetweenSymmetric returns an 'A BETWEEN SYMMETRIC X AND Y' Predicate. It only
+// accepts StringField.
+func (f StringField) BetweenSymmetric(start, end StringField) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateBetweenSymmetric,
+		Field:    f,
+		FieldX:   start,
+		FieldY:   end,
+	}
+}
+
+// BetweenSymmetricString returns an 'A BETWEEN SYMMETRIC X AND Y' Predicate. It
+// only accepts string.
+func (f StringField) BetweenSymmetricString(start, end string) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateBetweenSymmetric,
+		Field:    f,
+		FieldX:   String(start),
+		FieldY:   String(end),
+	}
+}
+
+// NotBetweenSymmetric returns an 'A NOT BETWEEN SYMMETRIC X AND Y' Predicate.
+// It only accepts StringField.
+func (f StringField) NotBetweenSymmetric(start, end StringField) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateNotBetweenSymmetric,
+		Field:    f,
+		FieldX:   start,
+		FieldY:   end,
+	}
+}
+
+// NotBetweenSymmetricString returns an 'A NOT BETWEEN SYMMETRIC X AND Y'
+// Predicate. It only accepts string.
+func (f StringField) NotBetweenSymmetricString(start, end string) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateNotBetweenSymmetric,
+		Field:    f,
+		FieldX:   String(start),
+		FieldY:   String(end),
+	}
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of a StringField.
 func (f StringField) String() string {
@@ -319,3 +528,9 @@ func (f StringField) GetAlias() string {
 func (f StringField) GetName() string {
 	return f.name
 }
+
+// quoteCollation quotes a collation name as an SQL identifier, since
+// collation names like "C" or "en_US" are case sensitive.
+func quoteCollation(collation string) string {
+	return `"` + strings.ReplaceAll(collation, `"`, `""`) + `"`
+}
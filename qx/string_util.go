@@ -2,12 +2,15 @@ package qx
 
 import (
 	"database/sql/driver"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math/rand"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 // TODO: rewrite this documentation
@@ -30,16 +33,18 @@ func defaultSprintf(format string, values []interface{}, excludeTableQualifiers
 		switch value := values[i].(type) {
 		case nil:
 			query, args = "NULL", nil
+		case DefaultKeyword:
+			query, args = "DEFAULT", nil
 		case Table:
 			query, args = value.ToSQL()
 		case Predicate:
 			query, args = value.ToSQL(excludeTableQualifiers)
-		case Field:
-			query, args = value.ToSQL(excludeTableQualifiers)
 		case Fields:
 			buf := &strings.Builder{}
 			value.WriteSQL(buf, &args, "", "", excludeTableQualifiers)
 			query = buf.String()
+		case Field:
+			query, args = value.ToSQL(excludeTableQualifiers)
 		case FieldValueSet:
 			sets := FieldValueSets{value}
 			buf := &strings.Builder{}
@@ -204,6 +209,8 @@ func ArgToString(arg interface{}) string {
 		// consider using the AppendFormat custom allocation trick here
 		// https://segment.com/blog/allocation-efficiency-in-high-performance-go-services/
 		str = "'" + v.Format(time.RFC3339Nano) + "'"
+	case Parameter:
+		str = v.String()
 	case driver.Valuer:
 		Interface, err := v.Value()
 		if err != nil {
@@ -241,6 +248,85 @@ func PostgresInterpolateSQL(query string, args ...interface{}) string {
 	return result
 }
 
+// QuoteValue returns the arg as an escaped Postgres literal that can be
+// embedded directly into a query, for statements that do not accept bind
+// parameters (such as COPY). Unlike ArgToString, which is only meant for
+// logging, it returns an error for types that it cannot safely quote.
+func QuoteValue(arg interface{}) (string, error) {
+	switch v := arg.(type) {
+	case nil:
+		return "NULL", nil
+	case bool:
+		if v {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64:
+		str := fmt.Sprint(v)
+		if strings.HasPrefix(str, "-") {
+			// negative numbers are parenthesized, otherwise 'a-?' would
+			// become 'a--1' which starts a comment
+			return "(" + str + ")", nil
+		}
+		return str, nil
+	case float32:
+		return pq.QuoteLiteral(strconv.FormatFloat(float64(v), 'g', -1, 32)) + "::FLOAT4", nil
+	case float64:
+		return pq.QuoteLiteral(strconv.FormatFloat(v, 'g', -1, 64)) + "::FLOAT8", nil
+	case string:
+		return pq.QuoteLiteral(v), nil
+	case []byte:
+		return pq.QuoteLiteral(`\x`+hex.EncodeToString(v)) + "::BYTEA", nil
+	case time.Time:
+		return pq.QuoteLiteral(v.Format(time.RFC3339Nano)) + "::TIMESTAMPTZ", nil
+	case Parameter:
+		return "", fmt.Errorf("parameter %s was not bound", v.Name)
+	case driver.Valuer:
+		value, err := v.Value()
+		if err != nil {
+			return "", err
+		}
+		return QuoteValue(value)
+	}
+	return "", fmt.Errorf("cannot quote a value of type %T", arg)
+}
+
+// PostgresInlineArgs is like MySQLToPostgresPlaceholders, except that each ?
+// placeholder is replaced by its arg quoted with QuoteValue instead of a $N
+// placeholder.
+func PostgresInlineArgs(query string, args ...interface{}) (string, error) {
+	buf := &strings.Builder{}
+	i := 0
+	for {
+		p := strings.Index(query, "?")
+		if p < 0 {
+			break
+		}
+		buf.WriteString(query[:p])
+		if len(query[p:]) > 1 && query[p:p+2] == "??" {
+			buf.WriteString("?")
+			query = query[p+2:]
+			continue
+		}
+		if i >= len(args) {
+			return "", fmt.Errorf("query has more placeholders than the %d args", len(args))
+		}
+		literal, err := QuoteValue(args[i])
+		if err != nil {
+			return "", fmt.Errorf("arg %d: %w", i+1, err)
+		}
+		buf.WriteString(literal)
+		query = query[p+1:]
+		i++
+	}
+	if i != len(args) {
+		return "", fmt.Errorf("query has %d placeholders but %d args", i, len(args))
+	}
+	buf.WriteString(query)
+	return buf.String(), nil
+}
+
 func MySQLInterpolateSQL(query string, args ...interface{}) string {
 	buf := &strings.Builder{}
 	// i is the position of the ? in the query
@@ -113,3 +113,41 @@ func TestInterpolateSQL(t *testing.T) {
 		})
 	}
 }
+
+func TestPostgresInlineArgs(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		query       string
+		args        []interface{}
+		want        string
+		wantErr     bool
+	}
+	tests := []TT{
+		{"basic types", "SELECT ?, ?, ?, ?", []interface{}{nil, true, 5, "bob"}, "SELECT NULL, TRUE, 5, 'bob'", false},
+		{"negative numbers", "SELECT a-?, ?", []interface{}{-1, int64(-2)}, "SELECT a-(-1), (-2)", false},
+		{"escaped quotes", "SELECT ?", []interface{}{"'; DROP TABLE users; --"}, "SELECT '''; DROP TABLE users; --'", false},
+		{"backslashes", "SELECT ?", []interface{}{`a\b`}, `SELECT  E'a\\b'`, false},
+		{"bytes", "SELECT ?", []interface{}{[]byte{0xde, 0xad}}, `SELECT  E'\\xdead'::BYTEA`, false},
+		{"time", "SELECT ?", []interface{}{time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)}, "SELECT '2020-01-02T03:04:05Z'::TIMESTAMPTZ", false},
+		{"driver.Valuer", "SELECT ?", []interface{}{sql.NullString{String: "x", Valid: true}}, "SELECT 'x'", false},
+		{"escaped placeholder", "SELECT ? ?? ?", []interface{}{1, 2}, "SELECT 1 ? 2", false},
+		{"too few args", "SELECT ?, ?", []interface{}{1}, "", true},
+		{"too many args", "SELECT ?", []interface{}{1, 2}, "", true},
+		{"unsupported type", "SELECT ?", []interface{}{struct{}{}}, "", true},
+		{"unbound parameter", "SELECT ?", []interface{}{Param("x")}, "", true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			got, err := PostgresInlineArgs(tt.query, tt.args...)
+			if tt.wantErr {
+				is.True(err != nil)
+				return
+			}
+			is.NoErr(err)
+			is.Equal(tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package qx
+
+// Subquery is a Field that represents a Query used as a scalar subquery i.e.
+// '(SELECT ...)'. The Query is always nested, so its placeholders are only
+// rebound by the outermost query and its args stay in order.
+type Subquery struct {
+	Query Query
+	Alias string
+}
+
+// ToSQL marshals the Subquery into an SQL query and args. The query is
+// parenthesized.
+func (f Subquery) ToSQL([]string) (string, []interface{}) {
+	if f.Query == nil {
+		return "NULL", nil
+	}
+	query, args := f.Query.NestThis().ToSQL()
+	if query == "" {
+		return "NULL", nil
+	}
+	return "(" + query + ")", args
+}
+
+// As returns a new Subquery with the new alias i.e. 'field AS Alias'.
+func (f Subquery) As(alias string) Subquery {
+	f.Alias = alias
+	return f
+}
+
+// GetAlias implements the Field interface. It returns the alias of the
+// Subquery.
+func (f Subquery) GetAlias() string {
+	return f.Alias
+}
+
+// GetName implements the Field interface. It always returns an empty string
+// because a Subquery does not have a name.
+func (f Subquery) GetName() string {
+	return ""
+}
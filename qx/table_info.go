@@ -7,10 +7,12 @@ type TableInfo struct {
 	Schema string
 	Name   string
 	Alias  string
-	// Fields contains a list of references to the table's fields. It is
-	// ordinarily populated by the generated code, but is not actually used
-	// anywhere in the codebase. It is only meant for the end user, to
-	// programatically loop through a table's fields.
+	// Fields contains references to the table's fields. Every field
+	// constructor (e.g. NewNumberField) appends the field it creates, and the
+	// generated code replaces the list with the fields themselves in column
+	// order. It is used to expand the table into all of its columns (see
+	// Star), or for the end user to programatically loop through a table's
+	// fields.
 	Fields []Field
 }
 
@@ -48,9 +50,57 @@ func (tbl *TableInfo) GetName() string {
 	return tbl.Name
 }
 
+// GetSchema returns the schema from the TableInfo.
+func (tbl *TableInfo) GetSchema() string {
+	if tbl == nil {
+		return ""
+	}
+	return tbl.Schema
+}
+
 // AssertBaseTable implements the BaseTable interface.
 func (tbl *TableInfo) AssertBaseTable() {}
 
 func (tbl *TableInfo) GetFields() []Field {
+	if tbl == nil {
+		return nil
+	}
 	return tbl.Fields
 }
+
+// Star returns all of the table's fields in column order, which is the
+// explicit equivalent of 'SELECT table.*'. Since the fields belong to the
+// table, they are qualified with the table's alias if it has one.
+func (tbl *TableInfo) Star() Fields {
+	if tbl == nil {
+		return nil
+	}
+	return derefFields(tbl.Fields)
+}
+
+// derefFields returns a copy of the fields with the pointers appended by the
+// field constructors replaced by the fields they point to, so that they can
+// be used like any other field.
+func derefFields(fields []Field) Fields {
+	if len(fields) == 0 {
+		return nil
+	}
+	derefed := make(Fields, len(fields))
+	for i, field := range fields {
+		switch v := field.(type) {
+		case *BooleanField:
+			derefed[i] = *v
+		case *JSONField:
+			derefed[i] = *v
+		case *NumberField:
+			derefed[i] = *v
+		case *StringField:
+			derefed[i] = *v
+		case *TimeField:
+			derefed[i] = *v
+		default:
+			derefed[i] = field
+		}
+	}
+	return derefed
+}
@@ -0,0 +1,71 @@
+package qx
+
+// TableSampleMethod represents the sampling methods available to TABLESAMPLE.
+type TableSampleMethod string
+
+// TableSampleMethods
+const (
+	TableSampleSystem    TableSampleMethod = "SYSTEM"
+	TableSampleBernoulli TableSampleMethod = "BERNOULLI"
+)
+
+// TableSample is a BaseTable that represents the 'table TABLESAMPLE method
+// (percentage) [REPEATABLE (seed)]' SQL construct. It embeds the BaseTable it
+// samples, so it can be used anywhere that BaseTable can be used in the FROM
+// or JOIN clauses. Since the TABLESAMPLE clause must come after the table
+// alias, the query builders are responsible for calling SampleSQL after
+// writing the table and its alias.
+type TableSample struct {
+	BaseTable
+	Method     TableSampleMethod
+	Percentage float64
+	Seed       *float64
+}
+
+// Sample returns a new TableSample sampling the BaseTable with the
+// SYSTEM method.
+func Sample(table BaseTable, percentage float64) TableSample {
+	return TableSample{
+		BaseTable:  table,
+		Method:     TableSampleSystem,
+		Percentage: percentage,
+	}
+}
+
+// System returns a new TableSample that uses the SYSTEM sampling method.
+func (tbl TableSample) System() TableSample {
+	tbl.Method = TableSampleSystem
+	return tbl
+}
+
+// Bernoulli returns a new TableSample that uses the BERNOULLI sampling method.
+func (tbl TableSample) Bernoulli() TableSample {
+	tbl.Method = TableSampleBernoulli
+	return tbl
+}
+
+// Repeatable returns a new TableSample that uses the seed to generate the
+// same sample across queries i.e. 'REPEATABLE (seed)'.
+func (tbl TableSample) Repeatable(seed float64) TableSample {
+	tbl.Seed = &seed
+	return tbl
+}
+
+// SampleSQL returns the 'TABLESAMPLE method (percentage) [REPEATABLE (seed)]'
+// clause of the TableSample. It returns an empty string if there is no
+// BaseTable to sample.
+func (tbl TableSample) SampleSQL() (string, []interface{}) {
+	if tbl.BaseTable == nil {
+		return "", nil
+	}
+	if tbl.Method == "" {
+		tbl.Method = TableSampleSystem
+	}
+	query := "TABLESAMPLE " + string(tbl.Method) + " (?)"
+	args := []interface{}{tbl.Percentage}
+	if tbl.Seed != nil {
+		query = query + " REPEATABLE (?)"
+		args = append(args, *tbl.Seed)
+	}
+	return query, args
+}
@@ -24,6 +24,12 @@ func USERS() TABLE_USERS {
 	tbl.EMAIL = NewStringField("email", tbl.TableInfo)
 	tbl.PASSWORD = NewStringField("password", tbl.TableInfo)
 	tbl.UID = NewNumberField("uid", tbl.TableInfo)
+	tbl.TableInfo.Fields = []Field{
+		tbl.DISPLAYNAME,
+		tbl.EMAIL,
+		tbl.PASSWORD,
+		tbl.UID,
+	}
 	return tbl
 }
 
@@ -53,6 +59,15 @@ func USER_ROLES() TABLE_USER_ROLES {
 	tbl.UID = NewNumberField("uid", tbl.TableInfo)
 	tbl.UPDATED_AT = NewTimeField("updated_at", tbl.TableInfo)
 	tbl.URID = NewNumberField("urid", tbl.TableInfo)
+	tbl.TableInfo.Fields = []Field{
+		tbl.COHORT,
+		tbl.CREATED_AT,
+		tbl.DELETED_AT,
+		tbl.ROLE,
+		tbl.UID,
+		tbl.UPDATED_AT,
+		tbl.URID,
+	}
 	return tbl
 }
 
@@ -72,6 +87,10 @@ func COHORT_ENUM() TABLE_COHORT_ENUM {
 	tbl := TABLE_COHORT_ENUM{TableInfo: NewTableInfo("public", "cohort_enum")}
 	tbl.COHORT = NewStringField("cohort", tbl.TableInfo)
 	tbl.INSERTION_ORDER = NewNumberField("insertion_order", tbl.TableInfo)
+	tbl.TableInfo.Fields = []Field{
+		tbl.COHORT,
+		tbl.INSERTION_ORDER,
+	}
 	return tbl
 }
 
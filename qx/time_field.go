@@ -2,9 +2,12 @@ package qx
 
 import (
 	"time"
+
+	"github.com/lib/pq"
 )
 
-// TimeField either represents a time column or a literal time.Time value.
+// TimeField either represents a time column, a time expression or a literal
+// time.Time value.
 type TimeField struct {
 	// TimeField will be one of the following:
 
@@ -27,6 +30,13 @@ type TimeField struct {
 	name       string
 	descending *bool
 	nullsfirst *bool
+
+	// 3) Time expression
+	// Examples of time expressions:
+	// | query                            | args |
+	// |----------------------------------|------|
+	// | (SELECT u.x FROM users AS u ...) |      |
+	expression Field
 }
 
 // ToSQL marshals a TimeField into an SQL query and args (as described in the
@@ -34,6 +44,16 @@ type TimeField struct {
 // appears in the excludeTableQualifiers list, the output column name will not
 // be table qualified.
 func (f TimeField) ToSQL(excludeTableQualifiers []string) (string, []interface{}) {
+	// 3) Time expression
+	if f.expression != nil {
+		return CustomField{
+			Format:       "?",
+			Values:       []interface{}{f.expression},
+			IsDesc:       f.descending,
+			IsNullsFirst: f.nullsfirst,
+		}.ToSQL(excludeTableQualifiers)
+	}
+
 	// 1) Literal time.Time value
 	if f.value != nil {
 		return "?", []interface{}{*f.value}
@@ -82,6 +102,15 @@ func NewTimeField(name string, tbl *TableInfo) TimeField {
 	return f
 }
 
+// TimeExpression returns a new TimeField representing an arbitrary Field (such
+// as a scalar Subquery), so that it can be used wherever a TimeField is
+// expected.
+func TimeExpression(field Field) TimeField {
+	return TimeField{
+		expression: field,
+	}
+}
+
 // Time returns a new TimeField representing a literal time.Time value.
 func Time(t time.Time) TimeField {
 	return TimeField{
@@ -113,6 +142,13 @@ func (f TimeField) As(alias string) TimeField {
 	return f
 }
 
+// Excluded returns a new TimeField that references the row proposed for
+// insertion in an INSERT ... ON CONFLICT DO UPDATE query i.e. 'EXCLUDED.field'.
+func (f TimeField) Excluded() TimeField {
+	f.table = &TableInfo{Alias: "EXCLUDED"}
+	return f
+}
+
 // Asc returns a new TimeField indicating that it should be ordered in
 // ascending order i.e. 'ORDER BY field ASC'.
 func (f TimeField) Asc() TimeField {
@@ -356,6 +392,69 @@ func (f TimeField) NotBetweenSymmetricTime(start, end time.Time) Predicate {
 	}
 }
 
+// InTime returns an 'A IN (B1, B2, B3...)' Predicate. It only accepts
+// []time.Time.
+func (f TimeField) InTime(times []time.Time) InPredicate {
+	values := make([]interface{}, len(times))
+	for i := range times {
+		values[i] = times[i]
+	}
+	return InPredicate{
+		Field:     f,
+		Values:    values,
+		Array:     pq.GenericArray{A: times},
+		ArrayType: "TIMESTAMPTZ",
+	}
+}
+
+// NotInTime returns an 'A NOT IN (B1, B2, B3...)' Predicate. It only accepts
+// []time.Time.
+func (f TimeField) NotInTime(times []time.Time) InPredicate {
+	p := f.InTime(times)
+	p.Negative = true
+	return p
+}
+
+// IsDistinctFrom returns an 'A IS DISTINCT FROM B' Predicate. It only accepts
+// TimeField.
+func (f TimeField) IsDistinctFrom(field TimeField) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsDistinctFrom,
+		LeftField:  f,
+		RightField: field,
+	}
+}
+
+// IsDistinctFromTime returns an 'A IS DISTINCT FROM B' Predicate. It only
+// accepts time.Time.
+func (f TimeField) IsDistinctFromTime(t time.Time) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsDistinctFrom,
+		LeftField:  f,
+		RightField: Time(t),
+	}
+}
+
+// IsNotDistinctFrom returns an 'A IS NOT DISTINCT FROM B' Predicate. It only
+// accepts TimeField.
+func (f TimeField) IsNotDistinctFrom(field TimeField) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsNotDistinctFrom,
+		LeftField:  f,
+		RightField: field,
+	}
+}
+
+// IsNotDistinctFromTime returns an 'A IS NOT DISTINCT FROM B' Predicate. It
+// only accepts time.Time.
+func (f TimeField) IsNotDistinctFromTime(t time.Time) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateIsNotDistinctFrom,
+		LeftField:  f,
+		RightField: Time(t),
+	}
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of a TimeField.
 func (f TimeField) String() string {
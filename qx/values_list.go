@@ -4,6 +4,14 @@ import (
 	"strings"
 )
 
+// DefaultKeyword is the type of the Default sentinel.
+type DefaultKeyword struct{}
+
+// Default is a sentinel value that is written out as the DEFAULT keyword
+// instead of a '?' placeholder, telling the database to use the column
+// default e.g. InsertQuery.Values(qx.Default, "bob").
+var Default = DefaultKeyword{}
+
 // ValuesList represents the VALUES (a, b, c...), (d, e, f...), (g, h, i...)
 // SQL clause.
 type ValuesList [][]interface{}
@@ -20,11 +28,14 @@ func (vl ValuesList) WriteSQL(buf *strings.Builder, args *[]interface{}, prepend
 		}
 		valueQueries, valueArgs := []string{}, []interface{}{}
 		for j := range vl[i] {
-			if field, ok := vl[i][j].(Field); ok && field != nil {
-				fieldQuery, fieldArgs := field.ToSQL(nil)
+			switch value := vl[i][j].(type) {
+			case DefaultKeyword:
+				valueQueries = append(valueQueries, "DEFAULT")
+			case Field:
+				fieldQuery, fieldArgs := value.ToSQL(nil)
 				valueQueries = append(valueQueries, fieldQuery)
 				valueArgs = append(valueArgs, fieldArgs...)
-			} else {
+			default:
 				valueQueries = append(valueQueries, "?")
 				valueArgs = append(valueArgs, vl[i][j])
 			}
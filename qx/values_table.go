@@ -0,0 +1,168 @@
+package qx
+
+import "strings"
+
+// ColumnAliaser is implemented by Tables whose output columns are named by
+// a column alias list written right after the table alias i.e. 'AS alias
+// (col1, col2...)'. The query builders are responsible for calling
+// GetColumnAliases after writing the table alias.
+type ColumnAliaser interface {
+	GetColumnAliases() []string
+}
+
+// ValuesTable is a Table that represents a VALUES list used in the FROM or
+// JOIN clauses i.e. '(VALUES (a, b), (c, d)) AS alias (col1, col2)'.
+//
+// Postgres resolves the type of each VALUES column from the values in it, and
+// a column consisting only of placeholders is resolved as text. If such a
+// column is compared against a non-text column, its values should carry an
+// explicit cast e.g. qy.Fieldf("?", id), which casts Go ints to INT.
+type ValuesTable struct {
+	ValuesList ValuesList
+	Alias      string
+	Columns    []string
+}
+
+// Values returns a new ValuesTable consisting of the rows. Use As to give it
+// an alias and column names.
+func Values(rows ...[]interface{}) ValuesTable {
+	return ValuesTable{ValuesList: rows}
+}
+
+// As returns a new ValuesTable with the alias and column names.
+func (tbl ValuesTable) As(alias string, columns ...string) ValuesTable {
+	tbl.Alias = alias
+	tbl.Columns = columns
+	return tbl
+}
+
+// NewValuesTable returns a new ValuesTable with the alias and column names.
+func NewValuesTable(valuesList ValuesList, alias string, columns ...string) ValuesTable {
+	return ValuesTable{
+		ValuesList: valuesList,
+		Alias:      alias,
+		Columns:    columns,
+	}
+}
+
+// ToSQL marshals the ValuesTable into an SQL query and args. The VALUES list
+// is parenthesized so that it can be aliased.
+func (tbl ValuesTable) ToSQL() (string, []interface{}) {
+	buf := &strings.Builder{}
+	var args []interface{}
+	if !tbl.ValuesList.WriteSQL(buf, &args, "(VALUES ", ")") {
+		return "", nil
+	}
+	return buf.String(), args
+}
+
+// GetAlias implements the Table interface. It returns the alias of the
+// ValuesTable.
+func (tbl ValuesTable) GetAlias() string {
+	return tbl.Alias
+}
+
+// GetName implements the Table interface. It always returns an empty string
+// because a ValuesTable does not have a name.
+func (tbl ValuesTable) GetName() string {
+	return ""
+}
+
+// GetColumnAliases implements the ColumnAliaser interface. It returns the
+// column names of the ValuesTable.
+func (tbl ValuesTable) GetColumnAliases() []string {
+	return tbl.Columns
+}
+
+// Field returns a Field referencing the column of the ValuesTable i.e.
+// 'alias.column'.
+func (tbl ValuesTable) Field(column string) CustomField {
+	if tbl.Alias == "" {
+		return CustomField{Format: column}
+	}
+	return CustomField{Format: tbl.Alias + "." + column}
+}
+
+// NumberField returns a NumberField referencing the column of the
+// ValuesTable.
+func (tbl ValuesTable) NumberField(column string) NumberField {
+	return NewNumberField(column, &TableInfo{Alias: tbl.Alias})
+}
+
+// StringField returns a StringField referencing the column of the
+// ValuesTable.
+func (tbl ValuesTable) StringField(column string) StringField {
+	return NewStringField(column, &TableInfo{Alias: tbl.Alias})
+}
+
+// TimeField returns a TimeField referencing the column of the ValuesTable.
+func (tbl ValuesTable) TimeField(column string) TimeField {
+	return NewTimeField(column, &TableInfo{Alias: tbl.Alias})
+}
+
+// BooleanField returns a BooleanField referencing the column of the
+// ValuesTable.
+func (tbl ValuesTable) BooleanField(column string) BooleanField {
+	return NewBooleanField(column, &TableInfo{Alias: tbl.Alias})
+}
+
+// JSONField returns a JSONField referencing the column of the ValuesTable.
+func (tbl ValuesTable) JSONField(column string) JSONField {
+	return NewJSONField(column, &TableInfo{Alias: tbl.Alias})
+}
+
+// ColumnAliasSQL returns the '(col1, col2...)' column alias list of the
+// Table if it implements the ColumnAliaser interface. Otherwise it returns an
+// empty string.
+func ColumnAliasSQL(table Table) string {
+	aliaser, ok := table.(ColumnAliaser)
+	if !ok {
+		return ""
+	}
+	columns := aliaser.GetColumnAliases()
+	if len(columns) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(columns, ", ") + ")"
+}
+
+// RowValue represents the ROW(a, b, c...) row constructor. Like a ValuesList
+// row, any Field is expanded in place, Default is written as the DEFAULT
+// keyword and everything else becomes a '?' placeholder.
+type RowValue []interface{}
+
+// Row returns a new RowValue consisting of the values.
+func Row(values ...interface{}) RowValue {
+	return RowValue(values)
+}
+
+// ToSQL marshals the RowValue into an SQL query and args.
+func (row RowValue) ToSQL(excludeTableQualifiers []string) (string, []interface{}) {
+	queries, args := make([]string, len(row)), []interface{}{}
+	for i := range row {
+		switch value := row[i].(type) {
+		case DefaultKeyword:
+			queries[i] = "DEFAULT"
+		case Field:
+			query, subargs := value.ToSQL(excludeTableQualifiers)
+			queries[i] = query
+			args = append(args, subargs...)
+		default:
+			queries[i] = "?"
+			args = append(args, value)
+		}
+	}
+	return "ROW(" + strings.Join(queries, ", ") + ")", args
+}
+
+// GetAlias implements the Field interface. It always returns an empty string
+// because RowValues do not have aliases.
+func (row RowValue) GetAlias() string {
+	return ""
+}
+
+// GetName implements the Field interface. It always returns an empty string
+// because RowValues do not have names.
+func (row RowValue) GetName() string {
+	return ""
+}
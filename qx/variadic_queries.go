@@ -67,3 +67,50 @@ func (q VariadicQuery) As(alias string) VariadicQuery {
 func (q VariadicQuery) Get(fieldName string) CustomField {
 	return CustomField{Format: q.Alias + "." + fieldName}
 }
+
+// GetSelectFields implements the SelectLister interface. It returns the select
+// Fields of the first Query, since that is where a set operation takes its
+// column names from.
+func (q VariadicQuery) GetSelectFields() []Field {
+	if len(q.Queries) == 0 {
+		return nil
+	}
+	if lister, ok := q.Queries[0].(SelectLister); ok {
+		return lister.GetSelectFields()
+	}
+	return nil
+}
+
+// GetNumber returns a NumberField referencing the column of the VariadicQuery
+// identified by name. If the name is not in the select list of the
+// VariadicQuery, or belongs to a Field of a different type, the error is
+// reported when the query is validated or executed.
+func (q VariadicQuery) GetNumber(name string) NumberField {
+	return NumberColumn(q, name)
+}
+
+// GetString is like GetNumber, but returns a StringField.
+func (q VariadicQuery) GetString(name string) StringField {
+	return StringColumn(q, name)
+}
+
+// GetTime is like GetNumber, but returns a TimeField.
+func (q VariadicQuery) GetTime(name string) TimeField {
+	return TimeColumn(q, name)
+}
+
+// GetBoolean is like GetNumber, but returns a BooleanField.
+func (q VariadicQuery) GetBoolean(name string) BooleanField {
+	return BooleanColumn(q, name)
+}
+
+// GetJSON is like GetNumber, but returns a JSONField.
+func (q VariadicQuery) GetJSON(name string) JSONField {
+	return JSONColumn(q, name)
+}
+
+// Field returns a Field referencing the column of the VariadicQuery that is produced
+// by the field in its select list, as described in ColumnOf.
+func (q VariadicQuery) Field(field Field) Field {
+	return ColumnOf(q, field)
+}
@@ -12,6 +12,7 @@ import (
 type Row interface {
 	ScanArray(array interface{}, f qx.Field)
 	ScanInto(dest interface{}, f qx.Field)
+	Values(fields ...qx.Field) []interface{}
 	// bool
 	Bool(qx.BooleanField) bool
 	Bool_(qx.Field) bool
@@ -121,16 +122,18 @@ func CustomSprintf(format string, values []interface{}, excludeTableQualifiers [
 		switch value := values[i].(type) {
 		case nil:
 			query, args = "NULL", nil
+		case qx.DefaultKeyword:
+			query, args = "DEFAULT", nil
 		case qx.Table:
 			query, args = value.ToSQL()
 		case qx.Predicate:
 			query, args = value.ToSQL(excludeTableQualifiers)
-		case qx.Field:
-			query, args = value.ToSQL(excludeTableQualifiers)
 		case qx.Fields:
 			buf := &strings.Builder{}
 			value.WriteSQL(buf, &args, "", "", excludeTableQualifiers)
 			query = buf.String()
+		case qx.Field:
+			query, args = value.ToSQL(excludeTableQualifiers)
 		case qx.FieldValueSet:
 			sets := qx.FieldValueSets{value}
 			buf := &strings.Builder{}
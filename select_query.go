@@ -14,9 +14,10 @@ type SelectQuery struct {
 	// WITH
 	CTEs qx.CTEs
 	// SELECT
-	SelectType   qx.SelectType
-	DistinctOn   qx.Fields
-	SelectFields qx.Fields
+	SelectType      qx.SelectType
+	DistinctOn      qx.Fields
+	SelectFields    qx.Fields
+	AliasDuplicates bool
 	// FROM
 	FromTable  qx.Table
 	JoinGroups qx.JoinGroups
@@ -32,9 +33,15 @@ type SelectQuery struct {
 	LimitValue *uint64
 	// OFFSET
 	OffsetValue *uint64
+	// FETCH FIRST
+	FetchValue    *uint64
+	FetchWithTies bool
 	// Exec
-	Mapper      func(Row)
-	Accumulator func()
+	Mapper         func(Row)
+	Accumulator    func()
+	AccumulatorErr func() error
+	// Parameters
+	ParamValues interface{}
 	// Logging
 	Log qx.Logger
 }
@@ -55,6 +62,9 @@ func (q SelectQuery) ToSQL() (string, []interface{}) {
 		// anything was successfully written into the buffer, then we know that
 		// there are valid selected present in the SELECT
 		tempBuf, tempArgs := &strings.Builder{}, []interface{}{}
+		if q.AliasDuplicates {
+			q.SelectFields = qx.AliasDuplicateColumns(q.SelectFields)
+		}
 		if q.SelectFields.WriteSQLWithAlias(tempBuf, &tempArgs, "", "", nil) {
 			if q.SelectType == "" {
 				q.SelectType = qx.SelectTypeDefault
@@ -89,10 +99,20 @@ func (q SelectQuery) ToSQL() (string, []interface{}) {
 			}
 			if q.FromTable.GetAlias() != "" {
 				buf.WriteString("FROM " + fromQuery + " AS " + q.FromTable.GetAlias())
+				if columnAliases := qx.ColumnAliasSQL(q.FromTable); columnAliases != "" {
+					buf.WriteString(" " + columnAliases)
+				}
 			} else {
 				buf.WriteString("FROM " + fromQuery)
 			}
 			args = append(args, fromArgs...)
+			if sample, ok := q.FromTable.(qx.TableSample); ok {
+				sampleQuery, sampleArgs := sample.SampleSQL()
+				if sampleQuery != "" {
+					buf.WriteString(" " + sampleQuery)
+					args = append(args, sampleArgs...)
+				}
+			}
 		}
 	}
 	// JOIN
@@ -123,6 +143,18 @@ func (q SelectQuery) ToSQL() (string, []interface{}) {
 		buf.WriteString("OFFSET ?")
 		args = append(args, *q.OffsetValue)
 	}
+	// FETCH FIRST
+	if q.FetchValue != nil {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		if q.FetchWithTies {
+			buf.WriteString("FETCH FIRST ? ROWS WITH TIES")
+		} else {
+			buf.WriteString("FETCH FIRST ? ROWS ONLY")
+		}
+		args = append(args, *q.FetchValue)
+	}
 	query := buf.String()
 	if !q.Nested {
 		query = qx.MySQLToPostgresPlaceholders(query)
@@ -156,6 +188,16 @@ func SelectDistinctOn(distinctFields ...qx.Field) func(...qx.Field) SelectQuery
 	}
 }
 
+// SelectAll returns a new SelectQuery selecting every column of the table,
+// written out as explicit qualified columns instead of 'table.*'.
+func SelectAll(table qx.BaseTable) SelectQuery {
+	fields := table.GetFields()
+	if t, ok := table.(interface{ Star() qx.Fields }); ok {
+		fields = t.Star()
+	}
+	return NewSelectQuery().Select(fields...).From(table)
+}
+
 func Selectx(mapper func(Row), accumulator func()) SelectQuery {
 	return NewSelectQuery().Selectx(mapper, accumulator)
 }
@@ -260,6 +302,11 @@ func (q SelectQuery) OrderBy(fields ...qx.Field) SelectQuery {
 	return q
 }
 
+func (q SelectQuery) OrderByUsing(field qx.Field, operator string) SelectQuery {
+	q.OrderByFields = append(q.OrderByFields, qx.OrderByUsing(field, operator))
+	return q
+}
+
 func (q SelectQuery) Limit(limit int) SelectQuery {
 	if limit < 0 {
 		limit = -limit
@@ -278,17 +325,93 @@ func (q SelectQuery) Offset(offset int) SelectQuery {
 	return q
 }
 
+func (q SelectQuery) FetchFirst(fetch int) SelectQuery {
+	if fetch < 0 {
+		fetch = -fetch
+	}
+	num := uint64(fetch)
+	q.FetchValue = &num
+	return q
+}
+
+func (q SelectQuery) WithTies() SelectQuery {
+	q.FetchWithTies = true
+	return q
+}
+
+// Validate checks the SelectQuery for mistakes that can be caught without
+// sending the query to the database. Exec calls Validate automatically, but
+// it can also be called manually if the query is executed from the output of
+// ToSQL.
+func (q SelectQuery) Validate() error {
+	if err := validateArgs(q); err != nil {
+		return err
+	}
+	if q.LimitValue != nil && q.FetchValue != nil {
+		return errors.New("LIMIT and FETCH FIRST cannot be used together")
+	}
+	if q.FetchWithTies {
+		if q.FetchValue == nil {
+			return errors.New("WITH TIES cannot be used without FETCH FIRST")
+		}
+		if len(q.OrderByFields) == 0 {
+			return errors.New("FETCH FIRST ... WITH TIES cannot be used without an ORDER BY clause")
+		}
+	}
+	if q.Nested && !q.AliasDuplicates {
+		// duplicate output columns are harmless in a top level SELECT since
+		// rows are scanned by position, but a query that nests this one has
+		// no way to tell them apart
+		if err := qx.CheckDuplicateColumns(q.SelectFields); err != nil {
+			return err
+		}
+	}
+	return validateNested(q.CTEs, append([]qx.Table{q.FromTable}, joinTables(q.JoinGroups)...)...)
+}
+
+// AliasDuplicateColumns makes the SelectQuery alias every table column in its
+// select list that shares its output name with another column as
+// 'tablealias_column', e.g. 'SELECT u.uid AS u_uid, ur.uid AS ur_uid'.
+func (q SelectQuery) AliasDuplicateColumns() SelectQuery {
+	q.AliasDuplicates = true
+	return q
+}
+
 func (q SelectQuery) Selectx(mapper func(Row), accumulator func()) SelectQuery {
 	q.Mapper = mapper
 	q.Accumulator = accumulator
 	return q
 }
 
+// SelectxErr is like Selectx, but the accumulator can return an error to
+// abort Exec with that error, or ErrStopIteration to stop reading rows
+// without an error.
+func (q SelectQuery) SelectxErr(mapper func(Row), accumulator func() error) SelectQuery {
+	q.Mapper = mapper
+	q.AccumulatorErr = accumulator
+	return q
+}
+
 func (q SelectQuery) SelectRowx(mapper func(Row)) SelectQuery {
 	q.Mapper = mapper
 	return q
 }
 
+// Bind sets the values of the qx.Parameters in the SelectQuery, keyed by their
+// names. They are bound into the args when the query is executed.
+func (q SelectQuery) Bind(values map[string]interface{}) SelectQuery {
+	q.ParamValues = values
+	return q
+}
+
+// BindStruct is like Bind, but takes the values from the fields of a struct
+// (or pointer to a struct). Fields are matched by their `db` tag or, failing
+// that, their name.
+func (q SelectQuery) BindStruct(v interface{}) SelectQuery {
+	q.ParamValues = v
+	return q
+}
+
 func (q SelectQuery) Exec(db qx.Queryer) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -303,6 +426,9 @@ func (q SelectQuery) Exec(db qx.Queryer) (err error) {
 	if q.Mapper == nil {
 		return errors.New("you can't call Exec without a mapper")
 	}
+	if err = q.Validate(); err != nil {
+		return err
+	}
 	r := &QyRow{QxRow: &qx.QxRow{}}
 	q.Mapper(r)                     // call the mapper once on the *Row to get all the selected that the user is interested in
 	q.SelectFields = r.QxRow.Fields // then, transfer the selected collected by *Row to the SelectQuery
@@ -312,6 +438,9 @@ func (q SelectQuery) Exec(db qx.Queryer) (err error) {
 		q.SelectFields = append(q.SelectFields, Fieldf("1"))
 	}
 	query, args := q.ToSQL()
+	if args, err = qx.BindParams(args, q.ParamValues); err != nil {
+		return err
+	}
 	rows, err := db.Query(query, args...)
 	if err != nil {
 		return err
@@ -330,12 +459,21 @@ func (q SelectQuery) Exec(db qx.Queryer) (err error) {
 		}
 		r.QxRow.Index = 0 // index must always be reset back to 0 before mapper is called
 		q.Mapper(r)
+		if q.AccumulatorErr != nil {
+			if err = q.AccumulatorErr(); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+			continue
+		}
 		if q.Accumulator == nil {
 			break
 		}
 		q.Accumulator()
 	}
-	if rowcount == 0 && q.Accumulator == nil {
+	if rowcount == 0 && q.Accumulator == nil && q.AccumulatorErr == nil {
 		return sql.ErrNoRows
 	}
 	return rows.Err()
@@ -350,6 +488,77 @@ func (q SelectQuery) Get(fieldName string) qx.CustomField {
 	return Fieldf(q.Alias + "." + fieldName)
 }
 
+// GetSelectFields implements the qx.SelectLister interface. It returns the
+// Fields in the select list of the SelectQuery.
+func (q SelectQuery) GetSelectFields() []qx.Field {
+	if q.AliasDuplicates {
+		return qx.AliasDuplicateColumns(q.SelectFields)
+	}
+	return q.SelectFields
+}
+
+// GetNumber returns a NumberField referencing the column of the SelectQuery
+// identified by name. If the name is not in the select list of the
+// SelectQuery, or belongs to a Field of a different type, the error is
+// reported when the query is validated or executed.
+func (q SelectQuery) GetNumber(name string) qx.NumberField {
+	return qx.NumberColumn(q, name)
+}
+
+// GetString is like GetNumber, but returns a StringField.
+func (q SelectQuery) GetString(name string) qx.StringField {
+	return qx.StringColumn(q, name)
+}
+
+// GetTime is like GetNumber, but returns a TimeField.
+func (q SelectQuery) GetTime(name string) qx.TimeField {
+	return qx.TimeColumn(q, name)
+}
+
+// GetBoolean is like GetNumber, but returns a BooleanField.
+func (q SelectQuery) GetBoolean(name string) qx.BooleanField {
+	return qx.BooleanColumn(q, name)
+}
+
+// GetJSON is like GetNumber, but returns a JSONField.
+func (q SelectQuery) GetJSON(name string) qx.JSONField {
+	return qx.JSONColumn(q, name)
+}
+
+// Field returns a Field referencing the column of the SelectQuery that is
+// produced by the field in its select list, as described in qx.ColumnOf.
+func (q SelectQuery) Field(field qx.Field) qx.Field {
+	return qx.ColumnOf(q, field)
+}
+
+// AsNumber returns the SelectQuery as a scalar subquery that can be used
+// wherever a NumberField is expected e.g. in a select list, predicate or SET
+// clause. The SelectQuery should select exactly one column and return at most
+// one row.
+func (q SelectQuery) AsNumber() qx.NumberField {
+	return qx.NumberExpression(qx.Subquery{Query: q})
+}
+
+// AsString is like AsNumber, but returns a StringField.
+func (q SelectQuery) AsString() qx.StringField {
+	return qx.StringExpression(qx.Subquery{Query: q})
+}
+
+// AsTime is like AsNumber, but returns a TimeField.
+func (q SelectQuery) AsTime() qx.TimeField {
+	return qx.TimeExpression(qx.Subquery{Query: q})
+}
+
+// AsBoolean is like AsNumber, but returns a BooleanField.
+func (q SelectQuery) AsBoolean() qx.BooleanField {
+	return qx.BooleanExpression(qx.Subquery{Query: q})
+}
+
+// AsJSON is like AsNumber, but returns a JSONField.
+func (q SelectQuery) AsJSON() qx.JSONField {
+	return qx.JSONExpression(qx.Subquery{Query: q})
+}
+
 func (q SelectQuery) GetAlias() string {
 	return q.Alias
 }
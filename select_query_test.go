@@ -1,13 +1,14 @@
 package qy
 
 import (
+	"errors"
 	"log"
 	"os"
 	"testing"
 
 	"github.com/bokwoon95/qx-postgres/qx"
 	"github.com/bokwoon95/qx-postgres/tables"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/matryer/is"
 )
 
@@ -48,6 +49,51 @@ func TestSelectQuery_Get(t *testing.T) {
 	}
 }
 
+func TestSelectQuery_TypedGet(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		f           qx.Field
+		wantQuery   string
+	}
+	u := tables.USERS().As("u")
+	q := Select(u.UID, u.DISPLAYNAME.As("name"), Fieldf("COUNT(*)").As("n")).From(u).As("q")
+	cte := qx.NewCTE("cte", q)
+	union := qx.VariadicQuery{Operator: qx.QueryUnion, Queries: []qx.Query{q, q}}.As("x")
+	tests := []TT{
+		{"SelectQuery GetNumber", q.GetNumber("uid"), "q.uid"},
+		{"SelectQuery GetString (alias)", q.GetString("name"), "q.name"},
+		{"SelectQuery Field", q.Field(u.UID).(qx.NumberField).Asc(), "q.uid ASC"},
+		{"SelectQuery Field (alias)", q.Field(u.DISPLAYNAME.As("name")), "q.name"},
+		{"SelectQuery GetNumber on a custom field", q.GetNumber("n"), "q.n"},
+		{"CTE GetNumber", cte.GetNumber("uid"), "cte.uid"},
+		{"AliasedCTE GetString", cte.As("c").GetString("name"), "c.name"},
+		{"VariadicQuery GetNumber", union.GetNumber("uid"), "x.uid"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, _ := tt.f.ToSQL(nil)
+			is.Equal(tt.wantQuery, gotQuery)
+		})
+	}
+	is := is.New(t)
+	is.True(qx.CheckColumn(q, "displayname", nil) != nil)                 // aliased away
+	is.True(qx.CheckColumn(q, "uid", qx.StringField{}) != nil)            // type mismatch
+	is.True(qx.CheckColumn(cte.As("c"), "email", nil) != nil)             // not selected
+	is.NoErr(qx.CheckColumn(Select(Fieldf("NOW()")).From(u), "now", nil)) // undetermined names are allowed
+
+	// a bad column reference is reported by Validate and Exec, not by a panic
+	bad := Select(u.UID).From(union).Where(union.GetTime("uid").IsNull())
+	var columnErr qx.ColumnError
+	is.True(errors.As(bad.Validate(), &columnErr))
+	is.Equal("uid", columnErr.Name)
+	db, _ := newFakeDB(t)
+	is.True(errors.As(bad.Selectx(func(Row) {}, nil).Exec(db), &columnErr))
+	is.True(errors.As(Select(q.Field(Fieldf("NOW()"))).From(q).Validate(), &columnErr)) // no name
+}
+
 func TestSelectQuery_With(t *testing.T) {
 	q := NewSelectQuery()
 	wantQuery, wantArgs := "", []interface{}{}
@@ -192,6 +238,57 @@ func TestSelectQuery_From(t *testing.T) {
 			wantQuery := "FROM public.users AS u"
 			return TT{DESCRIPTION, q, wantQuery, nil}
 		}(),
+		func() TT {
+			DESCRIPTION := "SelectAll expands to qualified columns"
+			cohorts := tables.COHORT_ENUM().As("c")
+			q := SelectAll(cohorts)
+			wantQuery := "SELECT c.cohort, c.insertion_order FROM public.cohort_enum AS c"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
+		func() TT {
+			DESCRIPTION := "Star can be mixed with other fields"
+			u, ur := tables.USERS(), tables.USER_ROLES().As("ur")
+			q := baseSelect.Select(append(u.Star(), ur.ROLE)...).From(u).Join(ur, ur.UID.Eq(u.UID))
+			wantQuery := "SELECT users.displayname, users.email, users.password, users.uid, ur.role" +
+				" FROM public.users JOIN public.user_roles AS ur ON ur.uid = users.uid"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
+		func() TT {
+			DESCRIPTION := "VALUES list"
+			v := qx.Values([]interface{}{1, "aaa"}, []interface{}{2, "bbb"}).As("v", "uid", "displayname")
+			q := baseSelect.Select(v.NumberField("uid"), v.StringField("displayname")).From(v)
+			wantQuery := "SELECT v.uid, v.displayname FROM (VALUES ($1, $2), ($3, $4)) AS v (uid, displayname)"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{1, "aaa", 2, "bbb"}}
+		}(),
+		func() TT {
+			DESCRIPTION := "generate_series WITH ORDINALITY"
+			s := qx.GenerateSeries(10, 30).WithOrdinality().As("s", "n", "idx")
+			q := baseSelect.Select(s.NumberField("n"), s.NumberField("idx")).From(s)
+			wantQuery := "SELECT s.n, s.idx FROM generate_series($1::BIGINT, $2::BIGINT) WITH ORDINALITY AS s (n, idx)"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{10, 30}}
+		}(),
+		func() TT {
+			DESCRIPTION := "generate_series of fields and floats"
+			u := tables.USERS().As("u")
+			s := qx.GenerateSeriesStep(u.UID, 10.5, 0.5).As("s", "n")
+			q := baseSelect.Select(s.NumberField("n")).From(u).CrossJoin(s)
+			wantQuery := "SELECT s.n FROM public.users AS u CROSS JOIN generate_series(u.uid, $1::NUMERIC, $2::NUMERIC) AS s (n)"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{10.5, 0.5}}
+		}(),
+		func() TT {
+			DESCRIPTION := "jsonb_to_recordset with a column definition list"
+			x := qx.JSONBToRecordset(`[{"uid":1,"name":"aaa"}]`).As("x", "uid INT", "name TEXT")
+			q := baseSelect.Select(x.NumberField("uid")).From(x).Where(x.StringField("name").EqString("aaa"))
+			wantQuery := "SELECT x.uid FROM jsonb_to_recordset($1::JSONB) AS x (uid INT, name TEXT) WHERE x.name = $2"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{`[{"uid":1,"name":"aaa"}]`, "aaa"}}
+		}(),
+		func() TT {
+			DESCRIPTION := "jsonb_to_recordset of a Go slice"
+			x := qx.JSONBToRecordset([]map[string]int{{"uid": 1}}).As("x", "uid INT")
+			q := baseSelect.Select(x.NumberField("uid")).From(x)
+			wantQuery := "SELECT x.uid FROM jsonb_to_recordset($1::JSONB) AS x (uid INT)"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{`[{"uid":1}]`}}
+		}(),
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -231,6 +328,23 @@ func TestSelectQuery_Joins(t *testing.T) {
 				" CROSS JOIN public.user_roles AS ur"
 			return TT{DESCRIPTION, q, wantQuery, nil}
 		}(),
+		func() TT {
+			DESCRIPTION := "joining unnest of multiple arrays"
+			u := tables.USERS().As("u")
+			x := qx.Unnest([]int{1, 2}, []string{"aaa", "bbb"}).As("x", "uid", "displayname")
+			q := baseSelect.From(u).Join(x, x.NumberField("uid").Eq(u.UID))
+			wantQuery := "FROM public.users AS u" +
+				" JOIN unnest($1::BIGINT[], $2::TEXT[]) AS x (uid, displayname) ON x.uid = u.uid"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{pq.Int64Array{1, 2}, pq.StringArray{"aaa", "bbb"}}}
+		}(),
+		func() TT {
+			DESCRIPTION := "joining jsonb_array_elements"
+			u := tables.USERS().As("u")
+			e := qx.JSONBArrayElements(qx.NewJSONField("data", &qx.TableInfo{Alias: "u"})).As("e")
+			q := baseSelect.From(u).CrossJoin(e)
+			wantQuery := "FROM public.users AS u CROSS JOIN jsonb_array_elements(u.data) AS e"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
 		func() TT {
 			DESCRIPTION := "joining a subquery with explicit alias"
 			ur, c := tables.USER_ROLES().As("ur"), tables.COHORT_ENUM().As("c")
@@ -478,6 +592,30 @@ func TestSelectQuery_OrderBy(t *testing.T) {
 			wantQuery := "ORDER BY u.uid, u.uid ASC, u.uid DESC, u.uid NULLS LAST, u.uid ASC NULLS FIRST, u.uid DESC NULLS LAST"
 			return TT{DESCRIPTION, q, wantQuery, nil}
 		}(),
+		func() TT {
+			DESCRIPTION := "Collate and OrderByUsing"
+			u := tables.USERS().As("u")
+			q := baseSelect.OrderBy(u.DISPLAYNAME.Collate("C").Desc()).OrderByUsing(u.UID, ">")
+			wantQuery := `ORDER BY u.displayname COLLATE "C" DESC, u.uid USING >`
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
+		func() TT {
+			DESCRIPTION := "ordering by a predicate"
+			a := tables.APPLICATIONS().As("a")
+			q := baseSelect.OrderBy(qx.OrderByPredicate(a.STATUS.EqString("accepted")).Desc().NullsLast(), a.APNID)
+			wantQuery := "ORDER BY (a.status = $1) DESC NULLS LAST, a.apnid"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{"accepted"}}
+		}(),
+		func() TT {
+			DESCRIPTION := "ordering by position and output name is consistent with DISTINCT ON"
+			ur := tables.USER_ROLES().As("ur")
+			q := baseSelect.SelectDistinctOn(qx.Position(1))(ur.UID, ur.UPDATED_AT.As("last_updated")).
+				From(ur).
+				OrderBy(qx.Position(1), qx.OutputName("last_updated").Desc())
+			wantQuery := "SELECT DISTINCT ON (1) ur.uid, ur.updated_at AS last_updated" +
+				" FROM public.user_roles AS ur ORDER BY 1, last_updated DESC"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -512,6 +650,152 @@ func TestSelectQuery_Limit_Offset(t *testing.T) {
 			wantQuery := "LIMIT $1 OFFSET $2"
 			return TT{DESCRIPTION, q, wantQuery, []interface{}{uint64(22), uint64(34)}}
 		}(),
+		func() TT {
+			DESCRIPTION := "Offset and fetch first with ties"
+			u := tables.USERS().As("u")
+			q := baseSelect.OrderBy(u.UID).Offset(5).FetchFirst(10).WithTies()
+			wantQuery := "ORDER BY u.uid OFFSET $1 FETCH FIRST $2 ROWS WITH TIES"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{uint64(5), uint64(10)}}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestSelectQuery_Validate(t *testing.T) {
+	is := is.New(t)
+	u := tables.USERS().As("u")
+	q := NewSelectQuery().Select(u.UID).From(u)
+	is.NoErr(q.Limit(5).Validate())
+	is.NoErr(q.OrderBy(u.UID).FetchFirst(5).WithTies().Validate())
+	is.True(q.FetchFirst(5).WithTies().Validate() != nil)  // WITH TIES without ORDER BY
+	is.True(q.OrderBy(u.UID).WithTies().Validate() != nil) // WITH TIES without FETCH FIRST
+	is.True(q.Limit(5).FetchFirst(5).Validate() != nil)    // LIMIT and FETCH FIRST
+}
+
+func TestSelectQuery_DuplicateColumns(t *testing.T) {
+	is := is.New(t)
+	u, ur := tables.USERS().As("u"), tables.USER_ROLES().As("ur")
+	q := Select(u.UID, ur.UID, ur.ROLE).From(u).Join(ur, ur.UID.Eq(u.UID))
+	is.NoErr(q.Validate())                                                       // top level duplicates are scanned by position
+	is.True(q.NestThis().(SelectQuery).Validate() != nil)                        // nested duplicates are ambiguous
+	is.True(Select(u.EMAIL).From(q.As("q")).Validate() != nil)                   // in a FROM subquery
+	is.True(Select(u.EMAIL).With(qx.NewCTE("cte", q)).From(u).Validate() != nil) // in a CTE
+	is.NoErr(Select(u.EMAIL).From(Select(u.UID.As("user_id"), ur.UID).From(u).Join(ur, ur.UID.Eq(u.UID)).As("q")).Validate())
+	is.True(qx.CheckColumn(q.As("q"), "uid", nil) != nil) // ambiguous column access
+
+	// AliasDuplicateColumns
+	q = q.AliasDuplicateColumns().As("q")
+	gotQuery, _ := q.ToSQL()
+	is.Equal("SELECT u.uid AS u_uid, ur.uid AS ur_uid, ur.role FROM public.users AS u JOIN public.user_roles AS ur ON ur.uid = u.uid", gotQuery)
+	is.NoErr(Select(u.EMAIL).From(q).Validate())
+	gotQuery, _ = q.GetNumber("ur_uid").ToSQL(nil)
+	is.Equal("q.ur_uid", gotQuery)
+
+	// RETURNING
+	del := DeleteFrom(ur).Using(u).Where(ur.UID.Eq(u.UID)).Returning(ur.UID, u.UID)
+	is.NoErr(del.Validate())
+	is.True(Select(u.EMAIL).With(qx.NewCTE("deleted", del)).From(u).Validate() != nil)
+	del = del.AliasDuplicateColumns()
+	gotQuery, _ = del.ToSQL()
+	is.Equal("DELETE FROM public.user_roles AS ur USING public.users AS u WHERE ur.uid = u.uid RETURNING ur.uid AS ur_uid, u.uid AS u_uid", gotQuery)
+	is.NoErr(Select(u.EMAIL).With(qx.NewCTE("deleted", del)).From(u).Validate())
+}
+
+func TestSelectQuery_TableSample(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           SelectQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	baseSelect := NewSelectQuery()
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "TABLESAMPLE in FROM"
+			u := tables.USERS().As("u")
+			q := baseSelect.Select(u.UID).From(qx.Sample(u, 10))
+			wantQuery := "SELECT u.uid FROM public.users AS u TABLESAMPLE SYSTEM ($1)"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{float64(10)}}
+		}(),
+		func() TT {
+			DESCRIPTION := "TABLESAMPLE in JOIN"
+			u, ur := tables.USERS().As("u"), tables.USER_ROLES().As("ur")
+			q := baseSelect.Select(u.UID).From(u).
+				Join(qx.Sample(ur, 2.5).Bernoulli().Repeatable(42), ur.UID.Eq(u.UID)).
+				Where(ur.ROLE.EqString("student"))
+			wantQuery := "SELECT u.uid FROM public.users AS u" +
+				" JOIN public.user_roles AS ur TABLESAMPLE BERNOULLI ($1) REPEATABLE ($2) ON ur.uid = u.uid" +
+				" WHERE ur.role = $3"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{2.5, float64(42), "student"}}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestSelectQuery_ScalarSubquery(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           qx.Query
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "subquery in the select list and where clause"
+			u, ur := tables.USERS().As("u"), tables.USER_ROLES().As("ur")
+			roleCount := Select(Fieldf("COUNT(*)")).From(ur).Where(ur.UID.Eq(u.UID), ur.ROLE.NeString("banned")).AsNumber()
+			latestCohort := Select(Fieldf("MAX(?)", ur.COHORT)).From(ur).Where(ur.UID.EqInt(1)).AsString()
+			q := Select(u.UID, roleCount.As("role_count")).
+				From(u).
+				Where(u.DISPLAYNAME.EqString("aaa"), roleCount.GtInt(2), u.EMAIL.Ne(latestCohort))
+			wantQuery := "SELECT u.uid, (SELECT COUNT(*) FROM public.user_roles AS ur WHERE ur.uid = u.uid AND ur.role <> $1) AS role_count" +
+				" FROM public.users AS u" +
+				" WHERE u.displayname = $2" +
+				" AND (SELECT COUNT(*) FROM public.user_roles AS ur WHERE ur.uid = u.uid AND ur.role <> $3) > $4" +
+				" AND u.email <> (SELECT MAX(ur.cohort) FROM public.user_roles AS ur WHERE ur.uid = $5)"
+			wantArgs := []interface{}{"banned", "aaa", "banned", 2, 1}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "correlated subquery in a SET clause"
+			u, ur := tables.USERS().As("u"), tables.USER_ROLES().As("ur")
+			latestRole := Select(Fieldf("MAX(?)", ur.ROLE)).From(ur).Where(ur.UID.Eq(u.UID), ur.URID.GtInt(3)).AsString()
+			q := Update(u).Set(u.DISPLAYNAME.Set(latestRole)).Where(u.EMAIL.EqString("aaa@email.com"))
+			wantQuery := "UPDATE public.users AS u SET displayname =" +
+				" (SELECT MAX(ur.role) FROM public.user_roles AS ur WHERE ur.uid = u.uid AND ur.urid > $1)" +
+				" WHERE u.email = $2"
+			wantArgs := []interface{}{3, "aaa@email.com"}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "ordering by a subquery"
+			u, ur := tables.USERS().As("u"), tables.USER_ROLES().As("ur")
+			latestRole := Select(Fieldf("MAX(?)", ur.ROLE)).From(ur).Where(ur.UID.Eq(u.UID)).AsString()
+			roleCount := Select(Fieldf("COUNT(*)")).From(ur).Where(ur.UID.Eq(u.UID)).AsNumber()
+			q := Select(u.UID).From(u).OrderBy(latestRole.Collate("C").Desc(), roleCount.Asc().NullsLast())
+			wantQuery := "SELECT u.uid FROM public.users AS u ORDER BY" +
+				" (SELECT MAX(ur.role) FROM public.user_roles AS ur WHERE ur.uid = u.uid) COLLATE \"C\" DESC," +
+				" (SELECT COUNT(*) FROM public.user_roles AS ur WHERE ur.uid = u.uid) ASC NULLS LAST"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -0,0 +1,269 @@
+package qy
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+)
+
+// DefaultStmtCacheCapacity is the number of prepared statements a StmtCache
+// keeps if no capacity is specified.
+const DefaultStmtCacheCapacity = 100
+
+// Preparer is an interface used to prepare statements. It is implemented by
+// *sql.DB, *sql.Tx and *sql.Conn.
+type Preparer interface {
+	qx.QueryerContext
+	qx.ExecerContext
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// StmtCacheOptions configures a StmtCache.
+type StmtCacheOptions struct {
+	// Capacity is the maximum number of prepared statements kept by the
+	// cache. When it is exceeded, the least recently used statement is
+	// closed. Defaults to DefaultStmtCacheCapacity.
+	Capacity int
+
+	// DisablePrepare turns off server-side prepared statements, so that
+	// every query is passed straight through to the database. This is needed
+	// when connecting through a pooler that does not support them, such as
+	// PgBouncer in transaction pooling mode.
+	DisablePrepare bool
+}
+
+// StmtCache wraps a *sql.DB, *sql.Tx or *sql.Conn so that queries executed
+// through it are prepared on first use and the *sql.Stmt is reused on
+// subsequent calls with the same SQL. Since qy generates the same SQL for the
+// same query shape, this saves Postgres from re-parsing and re-planning it.
+// A StmtCache implements qx.Queryer and qx.Execer, so it can be passed to the
+// Exec method of any query. It is safe for concurrent use: a statement that
+// is evicted while another goroutine is using it is only closed once that
+// goroutine is done with it.
+//
+// If a statement fails because its cached plan is stale (e.g. a column was
+// added to a table it selects from), it is prepared again and retried once.
+// The retry is skipped if the StmtCache wraps a *sql.Tx, because the failed
+// statement has already aborted the transaction.
+type StmtCache struct {
+	db             Preparer
+	inTx           bool
+	capacity       int
+	disablePrepare bool
+	mu             sync.Mutex
+	lru            *list.List // most recently used statements at the front
+	stmts          map[string]*list.Element
+}
+
+// stmtCacheEntry is the value stored in each element of StmtCache.lru.
+type stmtCacheEntry struct {
+	query   string
+	stmt    *sql.Stmt
+	refs    int  // the number of callers currently using stmt
+	evicted bool // stmt is closed once refs drops to 0
+}
+
+// NewStmtCache returns a new StmtCache that prepares statements on db.
+func NewStmtCache(db Preparer, opts StmtCacheOptions) *StmtCache {
+	if opts.Capacity <= 0 {
+		opts.Capacity = DefaultStmtCacheCapacity
+	}
+	_, inTx := db.(*sql.Tx)
+	return &StmtCache{
+		db:             db,
+		inTx:           inTx,
+		capacity:       opts.Capacity,
+		disablePrepare: opts.DisablePrepare,
+		lru:            list.New(),
+		stmts:          make(map[string]*list.Element),
+	}
+}
+
+// Query implements the qx.Queryer interface.
+func (c *StmtCache) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.QueryContext(context.Background(), query, args...)
+}
+
+// QueryContext implements the qx.QueryerContext interface.
+func (c *StmtCache) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if c.disablePrepare {
+		return c.db.QueryContext(ctx, query, args...)
+	}
+	var rows *sql.Rows
+	err := c.withStmt(ctx, query, func(stmt *sql.Stmt) (err error) {
+		// the rows keep the statement open until they are closed, even if
+		// it is closed by the cache in the meantime
+		rows, err = stmt.QueryContext(ctx, args...)
+		return err
+	})
+	return rows, err
+}
+
+// Exec implements the qx.Execer interface.
+func (c *StmtCache) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.ExecContext(context.Background(), query, args...)
+}
+
+// ExecContext implements the qx.ExecerContext interface.
+func (c *StmtCache) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if c.disablePrepare {
+		return c.db.ExecContext(ctx, query, args...)
+	}
+	var result sql.Result
+	err := c.withStmt(ctx, query, func(stmt *sql.Stmt) (err error) {
+		result, err = stmt.ExecContext(ctx, args...)
+		return err
+	})
+	return result, err
+}
+
+// withStmt calls fn with the cached statement for the query, which cannot be
+// closed until fn returns. If fn fails because the cached plan is stale, the
+// statement is prepared again and fn is retried once, unless the StmtCache
+// wraps a *sql.Tx.
+func (c *StmtCache) withStmt(ctx context.Context, query string, fn func(stmt *sql.Stmt) error) error {
+	entry, err := c.acquire(ctx, query)
+	if err != nil {
+		return err
+	}
+	err = fn(entry.stmt)
+	c.release(entry)
+	if !isCachedPlanError(err) {
+		return err
+	}
+	c.invalidate(entry)
+	if c.inTx {
+		return err
+	}
+	// the statement failed before it was executed, so it is safe to retry
+	if entry, err = c.acquire(ctx, query); err != nil {
+		return err
+	}
+	defer c.release(entry)
+	return fn(entry.stmt)
+}
+
+// Len returns the number of prepared statements in the cache.
+func (c *StmtCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Close empties the cache and closes every prepared statement in it, or
+// closes them once they are no longer in use. The underlying database handle
+// is not closed.
+func (c *StmtCache) Close() error {
+	c.mu.Lock()
+	var unused []*sql.Stmt
+	for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+		if entry := elem.Value.(*stmtCacheEntry); c.evict(entry) {
+			unused = append(unused, entry.stmt)
+		}
+	}
+	c.lru.Init()
+	c.stmts = make(map[string]*list.Element)
+	c.mu.Unlock()
+	var firstErr error
+	for _, stmt := range unused {
+		if err := stmt.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// acquire returns the cache entry for the query, preparing the statement if
+// it is not in the cache yet. The entry must be released with release.
+func (c *StmtCache) acquire(ctx context.Context, query string) (*stmtCacheEntry, error) {
+	c.mu.Lock()
+	if elem, ok := c.stmts[query]; ok {
+		c.lru.MoveToFront(elem)
+		entry := elem.Value.(*stmtCacheEntry)
+		entry.refs++
+		c.mu.Unlock()
+		return entry, nil
+	}
+	c.mu.Unlock()
+	// prepare outside the lock so that a slow round trip does not block
+	// queries that are already cached
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var unused []*sql.Stmt
+	c.mu.Lock()
+	entry := &stmtCacheEntry{query: query, stmt: stmt, refs: 1}
+	if elem, ok := c.stmts[query]; ok {
+		// another goroutine prepared the same query in the meantime
+		c.lru.MoveToFront(elem)
+		entry = elem.Value.(*stmtCacheEntry)
+		entry.refs++
+		unused = append(unused, stmt)
+	} else {
+		c.stmts[query] = c.lru.PushFront(entry)
+	}
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		evicted := oldest.Value.(*stmtCacheEntry)
+		c.lru.Remove(oldest)
+		delete(c.stmts, evicted.query)
+		if c.evict(evicted) {
+			unused = append(unused, evicted.stmt)
+		}
+	}
+	c.mu.Unlock()
+	for _, stmt := range unused {
+		stmt.Close()
+	}
+	return entry, nil
+}
+
+// release marks the entry as no longer used by the caller, and closes its
+// statement if it was evicted and this was its last user.
+func (c *StmtCache) release(entry *stmtCacheEntry) {
+	c.mu.Lock()
+	entry.refs--
+	closeStmt := entry.evicted && entry.refs == 0
+	c.mu.Unlock()
+	if closeStmt {
+		entry.stmt.Close()
+	}
+}
+
+// invalidate removes the entry from the cache if it is still the one cached
+// for its query, and closes its statement once it is no longer in use.
+func (c *StmtCache) invalidate(entry *stmtCacheEntry) {
+	c.mu.Lock()
+	if elem, ok := c.stmts[entry.query]; ok && elem.Value.(*stmtCacheEntry) == entry {
+		c.lru.Remove(elem)
+		delete(c.stmts, entry.query)
+	}
+	closeStmt := c.evict(entry)
+	c.mu.Unlock()
+	if closeStmt {
+		entry.stmt.Close()
+	}
+}
+
+// evict marks an entry that has been removed from the cache as evicted. It
+// reports whether the entry is unused, in which case the caller must close
+// its statement after releasing the lock. c.mu must be held.
+func (c *StmtCache) evict(entry *stmtCacheEntry) (unused bool) {
+	if entry.evicted {
+		return false
+	}
+	entry.evicted = true
+	return entry.refs == 0
+}
+
+// isCachedPlanError reports whether the error was caused by a prepared
+// statement whose result type changed after it was prepared, e.g. because a
+// column was added to a table it selects from.
+func isCachedPlanError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "cached plan must not change result type")
+}
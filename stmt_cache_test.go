@@ -0,0 +1,131 @@
+package qy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestStmtCache(t *testing.T) {
+	is := is.New(t)
+	db, d := newFakeDB(t)
+	defer db.Close()
+	cache := NewStmtCache(db, StmtCacheOptions{Capacity: 2})
+	defer cache.Close()
+
+	// statements are prepared once and reused
+	for i := 0; i < 3; i++ {
+		_, err := cache.Exec("UPDATE a SET x = $1", i)
+		is.NoErr(err)
+		rows, err := cache.Query("SELECT 1")
+		is.NoErr(err)
+		is.NoErr(rows.Close())
+	}
+	is.Equal(1, d.prepareCount("UPDATE a SET x = $1"))
+	is.Equal(1, d.prepareCount("SELECT 1"))
+	is.Equal(2, cache.Len())
+
+	// the least recently used statement is evicted
+	_, err := cache.Exec("UPDATE b SET x = $1", 1)
+	is.NoErr(err)
+	is.Equal(2, cache.Len())
+	_, err = cache.Exec("UPDATE a SET x = $1", 1)
+	is.NoErr(err)
+	is.Equal(2, d.prepareCount("UPDATE a SET x = $1"))
+	rows, err := cache.Query("SELECT 1")
+	is.NoErr(err)
+	is.NoErr(rows.Close())
+	is.Equal(2, d.prepareCount("SELECT 1"))
+
+	// a stale plan is prepared again and retried
+	d.mu.Lock()
+	d.stale["SELECT 1"] = true
+	d.mu.Unlock()
+	_, err = cache.Exec("SELECT 1")
+	is.NoErr(err)
+	is.Equal(3, d.prepareCount("SELECT 1"))
+}
+
+func TestStmtCache_Eviction(t *testing.T) {
+	is := is.New(t)
+	db, _ := newFakeDB(t)
+	defer db.Close()
+	cache := NewStmtCache(db, StmtCacheOptions{Capacity: 1})
+	defer cache.Close()
+
+	// a statement evicted while it is in use stays open until it is released
+	entry, err := cache.acquire(context.Background(), "UPDATE a SET x = $1")
+	is.NoErr(err)
+	_, err = cache.Exec("UPDATE b SET x = $1", 1)
+	is.NoErr(err)
+	is.Equal(1, cache.Len())
+	_, err = entry.stmt.Exec(1)
+	is.NoErr(err)
+	cache.release(entry)
+	_, err = entry.stmt.Exec(1)
+	is.True(err != nil) // closed once released
+
+	// statements are evicted and re-prepared by concurrent callers without
+	// being closed underneath them
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			query := fmt.Sprintf("UPDATE t%d SET x = $1", i%3)
+			if _, err := cache.Exec(query, i); err != nil {
+				errs <- err
+			}
+			rows, err := cache.Query(query, i)
+			if err != nil {
+				errs <- err
+				return
+			}
+			rows.Close()
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		is.NoErr(err)
+	}
+}
+
+func TestStmtCache_Tx(t *testing.T) {
+	is := is.New(t)
+	db, d := newFakeDB(t)
+	defer db.Close()
+	tx, err := db.Begin()
+	is.NoErr(err)
+	defer tx.Rollback()
+	cache := NewStmtCache(tx, StmtCacheOptions{})
+	defer cache.Close()
+
+	// a stale plan aborts the transaction, so it is not retried
+	d.mu.Lock()
+	d.stale["SELECT 1"] = true
+	d.mu.Unlock()
+	_, err = cache.Exec("SELECT 1")
+	is.True(isCachedPlanError(err))
+	is.Equal(1, d.prepareCount("SELECT 1"))
+	is.Equal(0, cache.Len())
+}
+
+func TestStmtCache_DisablePrepare(t *testing.T) {
+	is := is.New(t)
+	db, d := newFakeDB(t)
+	defer db.Close()
+	cache := NewStmtCache(db, StmtCacheOptions{DisablePrepare: true})
+	for i := 0; i < 3; i++ {
+		_, err := cache.Exec("UPDATE a SET x = $1", i)
+		is.NoErr(err)
+	}
+	is.Equal(0, cache.Len())
+	// the driver has no Exec of its own, so database/sql prepares and closes
+	// a statement for every call
+	is.Equal(3, d.prepareCount("UPDATE a SET x = $1"))
+}
@@ -41,6 +41,23 @@ func APPLICATIONS() TABLE_APPLICATIONS {
 	tbl.TEAM = qx.NewNumberField("team", tbl.TableInfo)
 	tbl.TEAM_NAME = qx.NewStringField("team_name", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.APNID,
+		tbl.COHORT,
+		tbl.CREATED_AT,
+		tbl.CREATOR,
+		tbl.DATA,
+		tbl.DELETED_AT,
+		tbl.MAGICSTRING,
+		tbl.PROJECT_IDEA,
+		tbl.PROJECT_LEVEL,
+		tbl.SCHEMA,
+		tbl.STATUS,
+		tbl.SUBMITTED,
+		tbl.TEAM,
+		tbl.TEAM_NAME,
+		tbl.UPDATED_AT,
+	}
 	return tbl
 }
 
@@ -50,6 +67,10 @@ func (tbl TABLE_APPLICATIONS) As(alias string) TABLE_APPLICATIONS {
 	return tbl2
 }
 
+func (tbl TABLE_APPLICATIONS) Excluded() TABLE_APPLICATIONS {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_APPLICATIONS_STATUS_ENUM struct {
 	*qx.TableInfo
 	STATUS qx.StringField
@@ -58,6 +79,9 @@ type TABLE_APPLICATIONS_STATUS_ENUM struct {
 func APPLICATIONS_STATUS_ENUM() TABLE_APPLICATIONS_STATUS_ENUM {
 	tbl := TABLE_APPLICATIONS_STATUS_ENUM{TableInfo: qx.NewTableInfo("public", "applications_status_enum")}
 	tbl.STATUS = qx.NewStringField("status", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.STATUS,
+	}
 	return tbl
 }
 
@@ -67,6 +91,10 @@ func (tbl TABLE_APPLICATIONS_STATUS_ENUM) As(alias string) TABLE_APPLICATIONS_ST
 	return tbl2
 }
 
+func (tbl TABLE_APPLICATIONS_STATUS_ENUM) Excluded() TABLE_APPLICATIONS_STATUS_ENUM {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_COHORT_ENUM struct {
 	*qx.TableInfo
 	COHORT          qx.StringField
@@ -77,6 +105,10 @@ func COHORT_ENUM() TABLE_COHORT_ENUM {
 	tbl := TABLE_COHORT_ENUM{TableInfo: qx.NewTableInfo("public", "cohort_enum")}
 	tbl.COHORT = qx.NewStringField("cohort", tbl.TableInfo)
 	tbl.INSERTION_ORDER = qx.NewNumberField("insertion_order", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.COHORT,
+		tbl.INSERTION_ORDER,
+	}
 	return tbl
 }
 
@@ -86,6 +118,10 @@ func (tbl TABLE_COHORT_ENUM) As(alias string) TABLE_COHORT_ENUM {
 	return tbl2
 }
 
+func (tbl TABLE_COHORT_ENUM) Excluded() TABLE_COHORT_ENUM {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_FORM_SCHEMA struct {
 	*qx.TableInfo
 	CREATED_AT qx.TimeField
@@ -108,6 +144,16 @@ func FORM_SCHEMA() TABLE_FORM_SCHEMA {
 	tbl.PERIOD = qx.NewNumberField("period", tbl.TableInfo)
 	tbl.SUBSECTION = qx.NewStringField("subsection", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.CREATED_AT,
+		tbl.DATA,
+		tbl.DELETED_AT,
+		tbl.FSID,
+		tbl.NAME,
+		tbl.PERIOD,
+		tbl.SUBSECTION,
+		tbl.UPDATED_AT,
+	}
 	return tbl
 }
 
@@ -117,6 +163,10 @@ func (tbl TABLE_FORM_SCHEMA) As(alias string) TABLE_FORM_SCHEMA {
 	return tbl2
 }
 
+func (tbl TABLE_FORM_SCHEMA) Excluded() TABLE_FORM_SCHEMA {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_FORM_SCHEMA_ROLES struct {
 	*qx.TableInfo
 	FSRID  qx.NumberField
@@ -129,6 +179,11 @@ func FORM_SCHEMA_ROLES() TABLE_FORM_SCHEMA_ROLES {
 	tbl.FSRID = qx.NewNumberField("fsrid", tbl.TableInfo)
 	tbl.ROLE = qx.NewStringField("role", tbl.TableInfo)
 	tbl.SCHEMA = qx.NewNumberField("schema", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.FSRID,
+		tbl.ROLE,
+		tbl.SCHEMA,
+	}
 	return tbl
 }
 
@@ -138,6 +193,10 @@ func (tbl TABLE_FORM_SCHEMA_ROLES) As(alias string) TABLE_FORM_SCHEMA_ROLES {
 	return tbl2
 }
 
+func (tbl TABLE_FORM_SCHEMA_ROLES) Excluded() TABLE_FORM_SCHEMA_ROLES {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_MEDIA struct {
 	*qx.TableInfo
 	CREATED_AT  qx.TimeField
@@ -156,6 +215,14 @@ func MEDIA() TABLE_MEDIA {
 	tbl.NAME = qx.NewStringField("name", tbl.TableInfo)
 	tbl.TYPE = qx.NewStringField("type", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.CREATED_AT,
+		tbl.DELETED_AT,
+		tbl.DESCRIPTION,
+		tbl.NAME,
+		tbl.TYPE,
+		tbl.UPDATED_AT,
+	}
 	return tbl
 }
 
@@ -165,6 +232,10 @@ func (tbl TABLE_MEDIA) As(alias string) TABLE_MEDIA {
 	return tbl2
 }
 
+func (tbl TABLE_MEDIA) Excluded() TABLE_MEDIA {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_MILESTONE_ENUM struct {
 	*qx.TableInfo
 	MILESTONE qx.StringField
@@ -173,6 +244,9 @@ type TABLE_MILESTONE_ENUM struct {
 func MILESTONE_ENUM() TABLE_MILESTONE_ENUM {
 	tbl := TABLE_MILESTONE_ENUM{TableInfo: qx.NewTableInfo("public", "milestone_enum")}
 	tbl.MILESTONE = qx.NewStringField("milestone", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.MILESTONE,
+	}
 	return tbl
 }
 
@@ -182,6 +256,10 @@ func (tbl TABLE_MILESTONE_ENUM) As(alias string) TABLE_MILESTONE_ENUM {
 	return tbl2
 }
 
+func (tbl TABLE_MILESTONE_ENUM) Excluded() TABLE_MILESTONE_ENUM {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_MIME_TYPE_ENUM struct {
 	*qx.TableInfo
 	TYPE qx.StringField
@@ -190,6 +268,9 @@ type TABLE_MIME_TYPE_ENUM struct {
 func MIME_TYPE_ENUM() TABLE_MIME_TYPE_ENUM {
 	tbl := TABLE_MIME_TYPE_ENUM{TableInfo: qx.NewTableInfo("public", "mime_type_enum")}
 	tbl.TYPE = qx.NewStringField("type", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.TYPE,
+	}
 	return tbl
 }
 
@@ -199,6 +280,10 @@ func (tbl TABLE_MIME_TYPE_ENUM) As(alias string) TABLE_MIME_TYPE_ENUM {
 	return tbl2
 }
 
+func (tbl TABLE_MIME_TYPE_ENUM) Excluded() TABLE_MIME_TYPE_ENUM {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_PERIODS struct {
 	*qx.TableInfo
 	COHORT     qx.StringField
@@ -223,6 +308,17 @@ func PERIODS() TABLE_PERIODS {
 	tbl.STAGE = qx.NewStringField("stage", tbl.TableInfo)
 	tbl.START_AT = qx.NewTimeField("start_at", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.COHORT,
+		tbl.CREATED_AT,
+		tbl.DELETED_AT,
+		tbl.END_AT,
+		tbl.MILESTONE,
+		tbl.PID,
+		tbl.STAGE,
+		tbl.START_AT,
+		tbl.UPDATED_AT,
+	}
 	return tbl
 }
 
@@ -232,6 +328,10 @@ func (tbl TABLE_PERIODS) As(alias string) TABLE_PERIODS {
 	return tbl2
 }
 
+func (tbl TABLE_PERIODS) Excluded() TABLE_PERIODS {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_PROJECT_CATEGORY_ENUM struct {
 	*qx.TableInfo
 	PROJECT_CATEGORY qx.StringField
@@ -240,6 +340,9 @@ type TABLE_PROJECT_CATEGORY_ENUM struct {
 func PROJECT_CATEGORY_ENUM() TABLE_PROJECT_CATEGORY_ENUM {
 	tbl := TABLE_PROJECT_CATEGORY_ENUM{TableInfo: qx.NewTableInfo("public", "project_category_enum")}
 	tbl.PROJECT_CATEGORY = qx.NewStringField("project_category", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.PROJECT_CATEGORY,
+	}
 	return tbl
 }
 
@@ -249,6 +352,10 @@ func (tbl TABLE_PROJECT_CATEGORY_ENUM) As(alias string) TABLE_PROJECT_CATEGORY_E
 	return tbl2
 }
 
+func (tbl TABLE_PROJECT_CATEGORY_ENUM) Excluded() TABLE_PROJECT_CATEGORY_ENUM {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_PROJECT_LEVEL_ENUM struct {
 	*qx.TableInfo
 	PROJECT_LEVEL qx.StringField
@@ -257,6 +364,9 @@ type TABLE_PROJECT_LEVEL_ENUM struct {
 func PROJECT_LEVEL_ENUM() TABLE_PROJECT_LEVEL_ENUM {
 	tbl := TABLE_PROJECT_LEVEL_ENUM{TableInfo: qx.NewTableInfo("public", "project_level_enum")}
 	tbl.PROJECT_LEVEL = qx.NewStringField("project_level", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.PROJECT_LEVEL,
+	}
 	return tbl
 }
 
@@ -266,6 +376,10 @@ func (tbl TABLE_PROJECT_LEVEL_ENUM) As(alias string) TABLE_PROJECT_LEVEL_ENUM {
 	return tbl2
 }
 
+func (tbl TABLE_PROJECT_LEVEL_ENUM) Excluded() TABLE_PROJECT_LEVEL_ENUM {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_ROLE_ENUM struct {
 	*qx.TableInfo
 	ROLE qx.StringField
@@ -274,6 +388,9 @@ type TABLE_ROLE_ENUM struct {
 func ROLE_ENUM() TABLE_ROLE_ENUM {
 	tbl := TABLE_ROLE_ENUM{TableInfo: qx.NewTableInfo("public", "role_enum")}
 	tbl.ROLE = qx.NewStringField("role", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.ROLE,
+	}
 	return tbl
 }
 
@@ -283,6 +400,10 @@ func (tbl TABLE_ROLE_ENUM) As(alias string) TABLE_ROLE_ENUM {
 	return tbl2
 }
 
+func (tbl TABLE_ROLE_ENUM) Excluded() TABLE_ROLE_ENUM {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_ROLE_FORMS struct {
 	*qx.TableInfo
 	CREATED_AT    qx.TimeField
@@ -307,6 +428,17 @@ func ROLE_FORMS() TABLE_ROLE_FORMS {
 	tbl.SUBMITTED = qx.NewBooleanField("submitted", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
 	tbl.USER_ROLE = qx.NewNumberField("user_role", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.CREATED_AT,
+		tbl.DATA,
+		tbl.DELETED_AT,
+		tbl.OVERRIDE_OPEN,
+		tbl.RFID,
+		tbl.SCHEMA,
+		tbl.SUBMITTED,
+		tbl.UPDATED_AT,
+		tbl.USER_ROLE,
+	}
 	return tbl
 }
 
@@ -316,6 +448,10 @@ func (tbl TABLE_ROLE_FORMS) As(alias string) TABLE_ROLE_FORMS {
 	return tbl2
 }
 
+func (tbl TABLE_ROLE_FORMS) Excluded() TABLE_ROLE_FORMS {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_SCHEMA_MIGRATIONS struct {
 	*qx.TableInfo
 	DIRTY   qx.BooleanField
@@ -326,6 +462,10 @@ func SCHEMA_MIGRATIONS() TABLE_SCHEMA_MIGRATIONS {
 	tbl := TABLE_SCHEMA_MIGRATIONS{TableInfo: qx.NewTableInfo("public", "schema_migrations")}
 	tbl.DIRTY = qx.NewBooleanField("dirty", tbl.TableInfo)
 	tbl.VERSION = qx.NewNumberField("version", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.DIRTY,
+		tbl.VERSION,
+	}
 	return tbl
 }
 
@@ -335,6 +475,10 @@ func (tbl TABLE_SCHEMA_MIGRATIONS) As(alias string) TABLE_SCHEMA_MIGRATIONS {
 	return tbl2
 }
 
+func (tbl TABLE_SCHEMA_MIGRATIONS) Excluded() TABLE_SCHEMA_MIGRATIONS {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_SESSIONS struct {
 	*qx.TableInfo
 	CREATED_AT qx.TimeField
@@ -347,6 +491,11 @@ func SESSIONS() TABLE_SESSIONS {
 	tbl.CREATED_AT = qx.NewTimeField("created_at", tbl.TableInfo)
 	tbl.HASH = qx.NewStringField("hash", tbl.TableInfo)
 	tbl.UID = qx.NewNumberField("uid", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.CREATED_AT,
+		tbl.HASH,
+		tbl.UID,
+	}
 	return tbl
 }
 
@@ -356,6 +505,10 @@ func (tbl TABLE_SESSIONS) As(alias string) TABLE_SESSIONS {
 	return tbl2
 }
 
+func (tbl TABLE_SESSIONS) Excluded() TABLE_SESSIONS {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_STAGE_ENUM struct {
 	*qx.TableInfo
 	STAGE qx.StringField
@@ -364,6 +517,9 @@ type TABLE_STAGE_ENUM struct {
 func STAGE_ENUM() TABLE_STAGE_ENUM {
 	tbl := TABLE_STAGE_ENUM{TableInfo: qx.NewTableInfo("public", "stage_enum")}
 	tbl.STAGE = qx.NewStringField("stage", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.STAGE,
+	}
 	return tbl
 }
 
@@ -373,6 +529,10 @@ func (tbl TABLE_STAGE_ENUM) As(alias string) TABLE_STAGE_ENUM {
 	return tbl2
 }
 
+func (tbl TABLE_STAGE_ENUM) Excluded() TABLE_STAGE_ENUM {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_TEAM_EVALUATE_SUBMISSION struct {
 	*qx.TableInfo
 	CREATED_AT    qx.TimeField
@@ -401,6 +561,19 @@ func TEAM_EVALUATE_SUBMISSION() TABLE_TEAM_EVALUATE_SUBMISSION {
 	tbl.SUBMITTED = qx.NewBooleanField("submitted", tbl.TableInfo)
 	tbl.TESID = qx.NewNumberField("tesid", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.CREATED_AT,
+		tbl.DATA,
+		tbl.DELETED_AT,
+		tbl.EVALUATEE,
+		tbl.EVALUATION,
+		tbl.EVALUATOR,
+		tbl.OVERRIDE_OPEN,
+		tbl.SCHEMA,
+		tbl.SUBMITTED,
+		tbl.TESID,
+		tbl.UPDATED_AT,
+	}
 	return tbl
 }
 
@@ -410,6 +583,10 @@ func (tbl TABLE_TEAM_EVALUATE_SUBMISSION) As(alias string) TABLE_TEAM_EVALUATE_S
 	return tbl2
 }
 
+func (tbl TABLE_TEAM_EVALUATE_SUBMISSION) Excluded() TABLE_TEAM_EVALUATE_SUBMISSION {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_TEAM_EVALUATE_TEAM struct {
 	*qx.TableInfo
 	EVALUATEE qx.NumberField
@@ -420,6 +597,10 @@ func TEAM_EVALUATE_TEAM() TABLE_TEAM_EVALUATE_TEAM {
 	tbl := TABLE_TEAM_EVALUATE_TEAM{TableInfo: qx.NewTableInfo("public", "team_evaluate_team")}
 	tbl.EVALUATEE = qx.NewNumberField("evaluatee", tbl.TableInfo)
 	tbl.EVALUATOR = qx.NewNumberField("evaluator", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.EVALUATEE,
+		tbl.EVALUATOR,
+	}
 	return tbl
 }
 
@@ -429,6 +610,10 @@ func (tbl TABLE_TEAM_EVALUATE_TEAM) As(alias string) TABLE_TEAM_EVALUATE_TEAM {
 	return tbl2
 }
 
+func (tbl TABLE_TEAM_EVALUATE_TEAM) Excluded() TABLE_TEAM_EVALUATE_TEAM {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_TEAM_FEEDBACK_TEAM struct {
 	*qx.TableInfo
 	CREATED_AT    qx.TimeField
@@ -457,6 +642,19 @@ func TEAM_FEEDBACK_TEAM() TABLE_TEAM_FEEDBACK_TEAM {
 	tbl.SUBMITTED = qx.NewBooleanField("submitted", tbl.TableInfo)
 	tbl.TFTID = qx.NewNumberField("tftid", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.CREATED_AT,
+		tbl.DATA,
+		tbl.DELETED_AT,
+		tbl.EVALUATEE,
+		tbl.EVALUATION,
+		tbl.EVALUATOR,
+		tbl.OVERRIDE_OPEN,
+		tbl.SCHEMA,
+		tbl.SUBMITTED,
+		tbl.TFTID,
+		tbl.UPDATED_AT,
+	}
 	return tbl
 }
 
@@ -466,6 +664,10 @@ func (tbl TABLE_TEAM_FEEDBACK_TEAM) As(alias string) TABLE_TEAM_FEEDBACK_TEAM {
 	return tbl2
 }
 
+func (tbl TABLE_TEAM_FEEDBACK_TEAM) Excluded() TABLE_TEAM_FEEDBACK_TEAM {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_TEAM_FEEDBACK_USER struct {
 	*qx.TableInfo
 	CREATED_AT    qx.TimeField
@@ -494,6 +696,19 @@ func TEAM_FEEDBACK_USER() TABLE_TEAM_FEEDBACK_USER {
 	tbl.SUBMITTED = qx.NewBooleanField("submitted", tbl.TableInfo)
 	tbl.TFUID = qx.NewNumberField("tfuid", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.CREATED_AT,
+		tbl.DATA,
+		tbl.DELETED_AT,
+		tbl.EVALUATEE,
+		tbl.EVALUATION,
+		tbl.EVALUATOR,
+		tbl.OVERRIDE_OPEN,
+		tbl.SCHEMA,
+		tbl.SUBMITTED,
+		tbl.TFUID,
+		tbl.UPDATED_AT,
+	}
 	return tbl
 }
 
@@ -503,6 +718,10 @@ func (tbl TABLE_TEAM_FEEDBACK_USER) As(alias string) TABLE_TEAM_FEEDBACK_USER {
 	return tbl2
 }
 
+func (tbl TABLE_TEAM_FEEDBACK_USER) Excluded() TABLE_TEAM_FEEDBACK_USER {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_TEAM_SUBMISSION_CATEGORIES struct {
 	*qx.TableInfo
 	CATEGORY   qx.StringField
@@ -515,6 +734,11 @@ func TEAM_SUBMISSION_CATEGORIES() TABLE_TEAM_SUBMISSION_CATEGORIES {
 	tbl.CATEGORY = qx.NewStringField("category", tbl.TableInfo)
 	tbl.SUBMISSION = qx.NewNumberField("submission", tbl.TableInfo)
 	tbl.TSCID = qx.NewNumberField("tscid", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.CATEGORY,
+		tbl.SUBMISSION,
+		tbl.TSCID,
+	}
 	return tbl
 }
 
@@ -524,6 +748,10 @@ func (tbl TABLE_TEAM_SUBMISSION_CATEGORIES) As(alias string) TABLE_TEAM_SUBMISSI
 	return tbl2
 }
 
+func (tbl TABLE_TEAM_SUBMISSION_CATEGORIES) Excluded() TABLE_TEAM_SUBMISSION_CATEGORIES {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_TEAM_SUBMISSIONS struct {
 	*qx.TableInfo
 	CREATED_AT    qx.TimeField
@@ -554,6 +782,20 @@ func TEAM_SUBMISSIONS() TABLE_TEAM_SUBMISSIONS {
 	tbl.TSID = qx.NewNumberField("tsid", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
 	tbl.VIDEO = qx.NewStringField("video", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.CREATED_AT,
+		tbl.DATA,
+		tbl.DELETED_AT,
+		tbl.OVERRIDE_OPEN,
+		tbl.POSTER,
+		tbl.README,
+		tbl.SCHEMA,
+		tbl.SUBMITTED,
+		tbl.TEAM,
+		tbl.TSID,
+		tbl.UPDATED_AT,
+		tbl.VIDEO,
+	}
 	return tbl
 }
 
@@ -563,6 +805,10 @@ func (tbl TABLE_TEAM_SUBMISSIONS) As(alias string) TABLE_TEAM_SUBMISSIONS {
 	return tbl2
 }
 
+func (tbl TABLE_TEAM_SUBMISSIONS) Excluded() TABLE_TEAM_SUBMISSIONS {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_TEAMS struct {
 	*qx.TableInfo
 	ADVISER       qx.NumberField
@@ -593,6 +839,20 @@ func TEAMS() TABLE_TEAMS {
 	tbl.TEAM_NAME = qx.NewStringField("team_name", tbl.TableInfo)
 	tbl.TID = qx.NewNumberField("tid", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.ADVISER,
+		tbl.COHORT,
+		tbl.CREATED_AT,
+		tbl.DATA,
+		tbl.DELETED_AT,
+		tbl.MENTOR,
+		tbl.PROJECT_IDEA,
+		tbl.PROJECT_LEVEL,
+		tbl.STATUS,
+		tbl.TEAM_NAME,
+		tbl.TID,
+		tbl.UPDATED_AT,
+	}
 	return tbl
 }
 
@@ -602,6 +862,10 @@ func (tbl TABLE_TEAMS) As(alias string) TABLE_TEAMS {
 	return tbl2
 }
 
+func (tbl TABLE_TEAMS) Excluded() TABLE_TEAMS {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_TEAMS_STATUS_ENUM struct {
 	*qx.TableInfo
 	STATUS qx.StringField
@@ -610,6 +874,9 @@ type TABLE_TEAMS_STATUS_ENUM struct {
 func TEAMS_STATUS_ENUM() TABLE_TEAMS_STATUS_ENUM {
 	tbl := TABLE_TEAMS_STATUS_ENUM{TableInfo: qx.NewTableInfo("public", "teams_status_enum")}
 	tbl.STATUS = qx.NewStringField("status", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.STATUS,
+	}
 	return tbl
 }
 
@@ -619,6 +886,10 @@ func (tbl TABLE_TEAMS_STATUS_ENUM) As(alias string) TABLE_TEAMS_STATUS_ENUM {
 	return tbl2
 }
 
+func (tbl TABLE_TEAMS_STATUS_ENUM) Excluded() TABLE_TEAMS_STATUS_ENUM {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_USER_EVALUATE_SUBMISSION struct {
 	*qx.TableInfo
 	CREATED_AT    qx.TimeField
@@ -647,6 +918,19 @@ func USER_EVALUATE_SUBMISSION() TABLE_USER_EVALUATE_SUBMISSION {
 	tbl.SUBMITTED = qx.NewBooleanField("submitted", tbl.TableInfo)
 	tbl.UESID = qx.NewNumberField("uesid", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.CREATED_AT,
+		tbl.DATA,
+		tbl.DELETED_AT,
+		tbl.EVALUATEE,
+		tbl.EVALUATION,
+		tbl.EVALUATOR,
+		tbl.OVERRIDE_OPEN,
+		tbl.SCHEMA,
+		tbl.SUBMITTED,
+		tbl.UESID,
+		tbl.UPDATED_AT,
+	}
 	return tbl
 }
 
@@ -656,6 +940,10 @@ func (tbl TABLE_USER_EVALUATE_SUBMISSION) As(alias string) TABLE_USER_EVALUATE_S
 	return tbl2
 }
 
+func (tbl TABLE_USER_EVALUATE_SUBMISSION) Excluded() TABLE_USER_EVALUATE_SUBMISSION {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_USER_ROLES struct {
 	*qx.TableInfo
 	COHORT     qx.StringField
@@ -676,6 +964,15 @@ func USER_ROLES() TABLE_USER_ROLES {
 	tbl.UID = qx.NewNumberField("uid", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
 	tbl.URID = qx.NewNumberField("urid", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.COHORT,
+		tbl.CREATED_AT,
+		tbl.DELETED_AT,
+		tbl.ROLE,
+		tbl.UID,
+		tbl.UPDATED_AT,
+		tbl.URID,
+	}
 	return tbl
 }
 
@@ -685,6 +982,10 @@ func (tbl TABLE_USER_ROLES) As(alias string) TABLE_USER_ROLES {
 	return tbl2
 }
 
+func (tbl TABLE_USER_ROLES) Excluded() TABLE_USER_ROLES {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_USER_ROLES_APPLICANTS struct {
 	*qx.TableInfo
 	APPLICATION qx.NumberField
@@ -699,6 +1000,12 @@ func USER_ROLES_APPLICANTS() TABLE_USER_ROLES_APPLICANTS {
 	tbl.DATA = qx.NewJSONField("data", tbl.TableInfo)
 	tbl.SCHEMA = qx.NewNumberField("schema", tbl.TableInfo)
 	tbl.URID = qx.NewNumberField("urid", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.APPLICATION,
+		tbl.DATA,
+		tbl.SCHEMA,
+		tbl.URID,
+	}
 	return tbl
 }
 
@@ -708,6 +1015,10 @@ func (tbl TABLE_USER_ROLES_APPLICANTS) As(alias string) TABLE_USER_ROLES_APPLICA
 	return tbl2
 }
 
+func (tbl TABLE_USER_ROLES_APPLICANTS) Excluded() TABLE_USER_ROLES_APPLICANTS {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_USER_ROLES_STUDENTS struct {
 	*qx.TableInfo
 	DATA qx.JSONField
@@ -720,6 +1031,11 @@ func USER_ROLES_STUDENTS() TABLE_USER_ROLES_STUDENTS {
 	tbl.DATA = qx.NewJSONField("data", tbl.TableInfo)
 	tbl.TEAM = qx.NewNumberField("team", tbl.TableInfo)
 	tbl.URID = qx.NewNumberField("urid", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.DATA,
+		tbl.TEAM,
+		tbl.URID,
+	}
 	return tbl
 }
 
@@ -729,6 +1045,10 @@ func (tbl TABLE_USER_ROLES_STUDENTS) As(alias string) TABLE_USER_ROLES_STUDENTS
 	return tbl2
 }
 
+func (tbl TABLE_USER_ROLES_STUDENTS) Excluded() TABLE_USER_ROLES_STUDENTS {
+	return tbl.As("EXCLUDED")
+}
+
 type TABLE_USERS struct {
 	*qx.TableInfo
 	DISPLAYNAME qx.StringField
@@ -743,6 +1063,12 @@ func USERS() TABLE_USERS {
 	tbl.EMAIL = qx.NewStringField("email", tbl.TableInfo)
 	tbl.PASSWORD = qx.NewStringField("password", tbl.TableInfo)
 	tbl.UID = qx.NewNumberField("uid", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.DISPLAYNAME,
+		tbl.EMAIL,
+		tbl.PASSWORD,
+		tbl.UID,
+	}
 	return tbl
 }
 
@@ -752,6 +1078,10 @@ func (tbl TABLE_USERS) As(alias string) TABLE_USERS {
 	return tbl2
 }
 
+func (tbl TABLE_USERS) Excluded() TABLE_USERS {
+	return tbl.As("EXCLUDED")
+}
+
 type VIEW_FUNCS struct {
 	*qx.TableInfo
 	ARGTYPES qx.StringField
@@ -766,6 +1096,12 @@ func FUNCS() VIEW_FUNCS {
 	tbl.NAME = qx.NewStringField("name", tbl.TableInfo)
 	tbl.RESTYPE = qx.NewStringField("restype", tbl.TableInfo)
 	tbl.SCHEMA = qx.NewStringField("schema", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.ARGTYPES,
+		tbl.NAME,
+		tbl.RESTYPE,
+		tbl.SCHEMA,
+	}
 	return tbl
 }
 
@@ -791,6 +1127,13 @@ func FUNCSS() VIEW_FUNCSS {
 	tbl.NAME = qx.NewStringField("name", tbl.TableInfo)
 	tbl.RESTYPE = qx.NewStringField("restype", tbl.TableInfo)
 	tbl.SCHEMA = qx.NewStringField("schema", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.ARGTYPES,
+		tbl.CODE,
+		tbl.NAME,
+		tbl.RESTYPE,
+		tbl.SCHEMA,
+	}
 	return tbl
 }
 
@@ -834,6 +1177,22 @@ func PG_ALL_FOREIGN_KEYS() VIEW_PG_ALL_FOREIGN_KEYS {
 	tbl.PK_SCHEMA_NAME = qx.NewStringField("pk_schema_name", tbl.TableInfo)
 	tbl.PK_TABLE_NAME = qx.NewStringField("pk_table_name", tbl.TableInfo)
 	tbl.PK_TABLE_OID = qx.NewNumberField("pk_table_oid", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.FK_CONSTRAINT_NAME,
+		tbl.FK_SCHEMA_NAME,
+		tbl.FK_TABLE_NAME,
+		tbl.FK_TABLE_OID,
+		tbl.IS_DEFERRABLE,
+		tbl.IS_DEFERRED,
+		tbl.MATCH_TYPE,
+		tbl.ON_DELETE,
+		tbl.ON_UPDATE,
+		tbl.PK_CONSTRAINT_NAME,
+		tbl.PK_INDEX_NAME,
+		tbl.PK_SCHEMA_NAME,
+		tbl.PK_TABLE_NAME,
+		tbl.PK_TABLE_OID,
+	}
 	return tbl
 }
 
@@ -873,6 +1232,20 @@ func TAP_FUNKY() VIEW_TAP_FUNKY {
 	tbl.RETURNS_SET = qx.NewBooleanField("returns_set", tbl.TableInfo)
 	tbl.SCHEMA = qx.NewStringField("schema", tbl.TableInfo)
 	tbl.VOLATILITY = qx.NewStringField("volatility", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.ARGS,
+		tbl.IS_DEFINER,
+		tbl.IS_STRICT,
+		tbl.IS_VISIBLE,
+		tbl.LANGOID,
+		tbl.NAME,
+		tbl.OID,
+		tbl.OWNER,
+		tbl.RETURNS,
+		tbl.RETURNS_SET,
+		tbl.SCHEMA,
+		tbl.VOLATILITY,
+	}
 	return tbl
 }
 
@@ -892,6 +1265,10 @@ func VIEWS() VIEW_VIEWS {
 	tbl := VIEW_VIEWS{TableInfo: qx.NewTableInfo("public", "views")}
 	tbl.NAME = qx.NewStringField("name", tbl.TableInfo)
 	tbl.SCHEMA = qx.NewStringField("schema", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.NAME,
+		tbl.SCHEMA,
+	}
 	return tbl
 }
 
@@ -913,6 +1290,11 @@ func VIEWSS() VIEW_VIEWSS {
 	tbl.CODE = qx.NewStringField("code", tbl.TableInfo)
 	tbl.NAME = qx.NewStringField("name", tbl.TableInfo)
 	tbl.SCHEMA = qx.NewStringField("schema", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.CODE,
+		tbl.NAME,
+		tbl.SCHEMA,
+	}
 	return tbl
 }
 
@@ -974,6 +1356,31 @@ func APP__V_ADVISER_EVALUATIONS() VIEW_APP__V_ADVISER_EVALUATIONS {
 	tbl.SUBMISSION_UPDATED_AT = qx.NewTimeField("submission_updated_at", tbl.TableInfo)
 	tbl.TSID = qx.NewNumberField("tsid", tbl.TableInfo)
 	tbl.UESID = qx.NewNumberField("uesid", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.COHORT,
+		tbl.EVALUATEE_PROJECT_LEVEL,
+		tbl.EVALUATEE_TEAM_NAME,
+		tbl.EVALUATEE_TID,
+		tbl.EVALUATION_ANSWERS,
+		tbl.EVALUATION_END_AT,
+		tbl.EVALUATION_OVERRIDE_OPEN,
+		tbl.EVALUATION_QUESTIONS,
+		tbl.EVALUATION_START_AT,
+		tbl.EVALUATION_SUBMITTED,
+		tbl.EVALUATION_UPDATED_AT,
+		tbl.EVALUATOR_DISPLAYNAME,
+		tbl.EVALUATOR_URID,
+		tbl.MILESTONE,
+		tbl.SUBMISSION_ANSWERS,
+		tbl.SUBMISSION_END_AT,
+		tbl.SUBMISSION_OVERRIDE_OPEN,
+		tbl.SUBMISSION_QUESTIONS,
+		tbl.SUBMISSION_START_AT,
+		tbl.SUBMISSION_SUBMITTED,
+		tbl.SUBMISSION_UPDATED_AT,
+		tbl.TSID,
+		tbl.UESID,
+	}
 	return tbl
 }
 
@@ -1031,6 +1438,29 @@ func APP__V_APPLICATIONS() VIEW_APP__V_APPLICATIONS {
 	tbl.STATUS = qx.NewStringField("status", tbl.TableInfo)
 	tbl.SUBMITTED = qx.NewBooleanField("submitted", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.APN_ANSWERS,
+		tbl.APNID,
+		tbl.APT1_ANSWERS,
+		tbl.APT1_DISPLAYNAME,
+		tbl.APT1_EMAIL,
+		tbl.APT1_UID,
+		tbl.APT1_URID,
+		tbl.APT2_ANSWERS,
+		tbl.APT2_DISPLAYNAME,
+		tbl.APT2_EMAIL,
+		tbl.APT2_UID,
+		tbl.APT2_URID,
+		tbl.COHORT,
+		tbl.CREATED_AT,
+		tbl.CREATOR,
+		tbl.DELETED_AT,
+		tbl.MAGICSTRING,
+		tbl.PROJECT_LEVEL,
+		tbl.STATUS,
+		tbl.SUBMITTED,
+		tbl.UPDATED_AT,
+	}
 	return tbl
 }
 
@@ -1088,6 +1518,29 @@ func APP__V_APPLICATIONS_V2() VIEW_APP__V_APPLICATIONS_V2 {
 	tbl.PROJECT_LEVEL = qx.NewStringField("project_level", tbl.TableInfo)
 	tbl.STATUS = qx.NewStringField("status", tbl.TableInfo)
 	tbl.SUBMITTED = qx.NewBooleanField("submitted", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.APNID,
+		tbl.APPLICANT1_DISPLAYNAME,
+		tbl.APPLICANT1_EMAIL,
+		tbl.APPLICANT1_FORM_DATA,
+		tbl.APPLICANT1_UID,
+		tbl.APPLICANT1_URID,
+		tbl.APPLICANT2_DISPLAYNAME,
+		tbl.APPLICANT2_EMAIL,
+		tbl.APPLICANT2_UID,
+		tbl.APPLICANT2_URID,
+		tbl.APPLICANT_FORM_SCHEMA,
+		tbl.APPLICANT_FSID,
+		tbl.APPLICATION_FORM_DATA,
+		tbl.APPLICATION_FORM_SCHEMA,
+		tbl.APPLICATION_FSID,
+		tbl.COHORT,
+		tbl.CREATOR,
+		tbl.MAGICSTRING,
+		tbl.PROJECT_LEVEL,
+		tbl.STATUS,
+		tbl.SUBMITTED,
+	}
 	return tbl
 }
 
@@ -1147,6 +1600,30 @@ func APP__V_MENTOR_EVALUATIONS() VIEW_APP__V_MENTOR_EVALUATIONS {
 	tbl.SUBMISSION_UPDATED_AT = qx.NewTimeField("submission_updated_at", tbl.TableInfo)
 	tbl.TSID = qx.NewNumberField("tsid", tbl.TableInfo)
 	tbl.UESID = qx.NewNumberField("uesid", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.COHORT,
+		tbl.EVALUATEE_TEAM_NAME,
+		tbl.EVALUATEE_TID,
+		tbl.EVALUATION_ANSWERS,
+		tbl.EVALUATION_END_AT,
+		tbl.EVALUATION_OVERRIDE_OPEN,
+		tbl.EVALUATION_QUESTIONS,
+		tbl.EVALUATION_START_AT,
+		tbl.EVALUATION_SUBMITTED,
+		tbl.EVALUATION_UPDATED_AT,
+		tbl.EVALUATOR_DISPLAYNAME,
+		tbl.EVALUATOR_URID,
+		tbl.MILESTONE,
+		tbl.SUBMISSION_ANSWERS,
+		tbl.SUBMISSION_END_AT,
+		tbl.SUBMISSION_OVERRIDE_OPEN,
+		tbl.SUBMISSION_QUESTIONS,
+		tbl.SUBMISSION_START_AT,
+		tbl.SUBMISSION_SUBMITTED,
+		tbl.SUBMISSION_UPDATED_AT,
+		tbl.TSID,
+		tbl.UESID,
+	}
 	return tbl
 }
 
@@ -1184,6 +1661,19 @@ func APP__V_QUESTIONS() VIEW_APP__V_QUESTIONS {
 	tbl.STAGE = qx.NewStringField("stage", tbl.TableInfo)
 	tbl.START_AT = qx.NewTimeField("start_at", tbl.TableInfo)
 	tbl.SUBSECTION = qx.NewStringField("subsection", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.COHORT,
+		tbl.DATA,
+		tbl.END_AT,
+		tbl.FSID,
+		tbl.MILESTONE,
+		tbl.MILESTONE_END_AT,
+		tbl.MILESTONE_START_AT,
+		tbl.NAME,
+		tbl.STAGE,
+		tbl.START_AT,
+		tbl.SUBSECTION,
+	}
 	return tbl
 }
 
@@ -1209,6 +1699,13 @@ func APP__V_SESSIONS() VIEW_APP__V_SESSIONS {
 	tbl.HASH = qx.NewStringField("hash", tbl.TableInfo)
 	tbl.ROLE = qx.NewStringField("role", tbl.TableInfo)
 	tbl.UID = qx.NewNumberField("uid", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.CREATED_AT,
+		tbl.DISPLAYNAME,
+		tbl.HASH,
+		tbl.ROLE,
+		tbl.UID,
+	}
 	return tbl
 }
 
@@ -1246,6 +1743,19 @@ func APP__V_SUBMISSIONS() VIEW_APP__V_SUBMISSIONS {
 	tbl.TEAM_NAME = qx.NewStringField("team_name", tbl.TableInfo)
 	tbl.TID = qx.NewNumberField("tid", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.ANSWERS,
+		tbl.COHORT,
+		tbl.END_AT,
+		tbl.MILESTONE,
+		tbl.OVERRIDE_OPEN,
+		tbl.QUESTIONS,
+		tbl.START_AT,
+		tbl.SUBMITTED,
+		tbl.TEAM_NAME,
+		tbl.TID,
+		tbl.UPDATED_AT,
+	}
 	return tbl
 }
 
@@ -1311,6 +1821,33 @@ func APP__V_TEAM_EVALUATIONS() VIEW_APP__V_TEAM_EVALUATIONS {
 	tbl.SUBMISSION_UPDATED_AT = qx.NewTimeField("submission_updated_at", tbl.TableInfo)
 	tbl.TESID = qx.NewNumberField("tesid", tbl.TableInfo)
 	tbl.TSID = qx.NewNumberField("tsid", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.COHORT,
+		tbl.EVALUATEE_PROJECT_LEVEL,
+		tbl.EVALUATEE_TEAM_NAME,
+		tbl.EVALUATEE_TID,
+		tbl.EVALUATION_ANSWERS,
+		tbl.EVALUATION_END_AT,
+		tbl.EVALUATION_OVERRIDE_OPEN,
+		tbl.EVALUATION_QUESTIONS,
+		tbl.EVALUATION_START_AT,
+		tbl.EVALUATION_SUBMITTED,
+		tbl.EVALUATION_UPDATED_AT,
+		tbl.EVALUATOR_PROJECT_LEVEL,
+		tbl.EVALUATOR_TEAM_NAME,
+		tbl.EVALUATOR_TID,
+		tbl.MILESTONE,
+		tbl.STAGE,
+		tbl.SUBMISSION_ANSWERS,
+		tbl.SUBMISSION_END_AT,
+		tbl.SUBMISSION_OVERRIDE_OPEN,
+		tbl.SUBMISSION_QUESTIONS,
+		tbl.SUBMISSION_START_AT,
+		tbl.SUBMISSION_SUBMITTED,
+		tbl.SUBMISSION_UPDATED_AT,
+		tbl.TESID,
+		tbl.TSID,
+	}
 	return tbl
 }
 
@@ -1380,6 +1917,35 @@ func APP__V_TEAMS() VIEW_APP__V_TEAMS {
 	tbl.TEAM_NAME = qx.NewStringField("team_name", tbl.TableInfo)
 	tbl.TID = qx.NewNumberField("tid", tbl.TableInfo)
 	tbl.UPDATED_AT = qx.NewTimeField("updated_at", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.ADV_DISPLAYNAME,
+		tbl.ADV_EMAIL,
+		tbl.ADV_UID,
+		tbl.ADV_URID,
+		tbl.COHORT,
+		tbl.CREATED_AT,
+		tbl.DELETED_AT,
+		tbl.MNT_DISPLAYNAME,
+		tbl.MNT_EMAIL,
+		tbl.MNT_UID,
+		tbl.MNT_URID,
+		tbl.PROJECT_LEVEL,
+		tbl.STATUS,
+		tbl.STU1_DATA,
+		tbl.STU1_DISPLAYNAME,
+		tbl.STU1_EMAIL,
+		tbl.STU1_UID,
+		tbl.STU1_URID,
+		tbl.STU2_DATA,
+		tbl.STU2_DISPLAYNAME,
+		tbl.STU2_EMAIL,
+		tbl.STU2_UID,
+		tbl.STU2_URID,
+		tbl.TEAM_DATA,
+		tbl.TEAM_NAME,
+		tbl.TID,
+		tbl.UPDATED_AT,
+	}
 	return tbl
 }
 
@@ -1409,6 +1975,15 @@ func APP__V_TEAMS_AND_STUDENTS() VIEW_APP__V_TEAMS_AND_STUDENTS {
 	tbl.STU2_DISPLAYNAME = qx.NewStringField("stu2_displayname", tbl.TableInfo)
 	tbl.TEAM_NAME = qx.NewStringField("team_name", tbl.TableInfo)
 	tbl.TID = qx.NewNumberField("tid", tbl.TableInfo)
+	tbl.TableInfo.Fields = []qx.Field{
+		tbl.ADVISER,
+		tbl.MENTOR,
+		tbl.PROJECT_LEVEL,
+		tbl.STU1_DISPLAYNAME,
+		tbl.STU2_DISPLAYNAME,
+		tbl.TEAM_NAME,
+		tbl.TID,
+	}
 	return tbl
 }
 
@@ -0,0 +1,168 @@
+package qy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+	"github.com/lib/pq"
+)
+
+// TxOptions configures Tx.
+type TxOptions struct {
+	// Isolation is the isolation level of the transaction. Defaults to the
+	// isolation level of the database, usually READ COMMITTED.
+	Isolation sql.IsolationLevel
+
+	// ReadOnly starts a READ ONLY transaction.
+	ReadOnly bool
+
+	// MaxRetries is the number of times the function is retried after a
+	// serialization failure (40001) or a deadlock (40P01). Defaults to 3, a
+	// negative number disables retries.
+	MaxRetries int
+
+	// MinBackoff and MaxBackoff bound how long Tx waits before each retry.
+	// The wait doubles after each retry, plus up to 50% jitter. They default
+	// to 10 milliseconds and 1 second.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// TxBeginner is an interface used to start transactions, implemented by
+// *sql.DB and *sql.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// savepointID is used to give every savepoint a unique name.
+var savepointID int64
+
+// Tx runs fn inside a transaction. The transaction is committed if fn returns
+// nil and rolled back if it returns an error or panics, in which case the
+// panic is returned as an error. The *sql.Tx can be passed directly to the
+// Exec methods of the queries in this package.
+//
+// If db is a TxBeginner a new transaction is started, and if fn or the
+// COMMIT fails with a serialization failure or a deadlock the whole
+// transaction is retried, so fn must be safe to run more than once.
+//
+// If db is a *sql.Tx, fn runs inside a SAVEPOINT of that transaction instead:
+// the savepoint is released if fn returns nil and rolled back to otherwise,
+// leaving the outer transaction usable. Savepoints are not retried, since a
+// serialization failure can only be resolved by retrying the outer
+// transaction, and the isolation level and read only options are ignored.
+func Tx(ctx context.Context, db qx.ExecerContext, opts TxOptions, fn func(tx *sql.Tx) error) error {
+	if tx, ok := db.(*sql.Tx); ok {
+		return savepoint(ctx, tx, fn)
+	}
+	beginner, ok := db.(TxBeginner)
+	if !ok {
+		return fmt.Errorf("Tx requires a *sql.DB, *sql.Conn or *sql.Tx, got %T", db)
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = 3
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = 10 * time.Millisecond
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = time.Second
+	}
+	backoff := opts.MinBackoff
+	for retries := 0; ; retries++ {
+		err := transaction(ctx, beginner, opts, fn)
+		if err == nil || !IsRetryable(err) || retries >= opts.MaxRetries {
+			return err
+		}
+		wait := backoff + time.Duration(rand.Int63n(int64(backoff)/2+1))
+		if wait > opts.MaxBackoff {
+			wait = opts.MaxBackoff
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+		if backoff *= 2; backoff > opts.MaxBackoff {
+			backoff = opts.MaxBackoff
+		}
+	}
+}
+
+// transaction runs fn once inside a new transaction.
+func transaction(ctx context.Context, db TxBeginner, opts TxOptions, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: opts.Isolation, ReadOnly: opts.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(r)
+		}
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	if err = fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// savepoint runs fn inside a new savepoint of the transaction.
+func savepoint(ctx context.Context, tx *sql.Tx, fn func(tx *sql.Tx) error) (err error) {
+	name := "qy_savepoint_" + strconv.FormatInt(atomic.AddInt64(&savepointID, 1), 10)
+	if _, err = tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(r)
+		}
+		if err != nil {
+			// the savepoint is rolled back to even if ctx is done, otherwise
+			// the outer transaction is left in an aborted state
+			tx.ExecContext(context.Background(), "ROLLBACK TO SAVEPOINT "+name)
+		}
+	}()
+	if err = fn(tx); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
+
+// panicError converts a recovered panic into an error.
+func panicError(r interface{}) error {
+	switch v := r.(type) {
+	case error:
+		return v
+	case string:
+		return errors.New(v)
+	default:
+		return fmt.Errorf("%v", v)
+	}
+}
+
+// IsRetryable reports whether err is a serialization failure (40001) or a
+// deadlock (40P01), after which the transaction can be retried.
+func IsRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code {
+	case "40001", "40P01":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package qy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/matryer/is"
+)
+
+func TestTx(t *testing.T) {
+	t.Run("commit", func(t *testing.T) {
+		t.Parallel()
+		is := is.New(t)
+		db, d := newFakeDB(t)
+		err := Tx(context.Background(), db, TxOptions{ReadOnly: true}, func(tx *sql.Tx) error {
+			return Notify("events", "hello").Exec(tx)
+		})
+		is.NoErr(err)
+		is.Equal([]string{"BEGIN READ ONLY", "SELECT pg_notify($1, $2)", "COMMIT"}, d.getLog())
+	})
+	t.Run("rollback on panic", func(t *testing.T) {
+		t.Parallel()
+		is := is.New(t)
+		db, d := newFakeDB(t)
+		err := Tx(context.Background(), db, TxOptions{}, func(tx *sql.Tx) error {
+			panic("oops")
+		})
+		is.Equal("oops", err.Error())
+		is.Equal([]string{"BEGIN", "ROLLBACK"}, d.getLog())
+	})
+	t.Run("retry", func(t *testing.T) {
+		t.Parallel()
+		is := is.New(t)
+		db, d := newFakeDB(t)
+		var calls int
+		err := Tx(context.Background(), db, TxOptions{MinBackoff: time.Millisecond}, func(tx *sql.Tx) error {
+			calls++
+			if calls < 3 {
+				return fmt.Errorf("wrapped: %w", &pq.Error{Code: "40001"})
+			}
+			return nil
+		})
+		is.NoErr(err)
+		is.Equal(3, calls)
+		is.Equal([]string{"BEGIN", "ROLLBACK", "BEGIN", "ROLLBACK", "BEGIN", "COMMIT"}, d.getLog())
+
+		calls = 0
+		err = Tx(context.Background(), db, TxOptions{MaxRetries: 1, MinBackoff: time.Millisecond}, func(tx *sql.Tx) error {
+			calls++
+			return &pq.Error{Code: "40P01"}
+		})
+		is.True(IsRetryable(err))
+		is.Equal(2, calls)
+
+		calls = 0
+		err = Tx(context.Background(), db, TxOptions{}, func(tx *sql.Tx) error {
+			calls++
+			return &pq.Error{Code: "23505"} // unique_violation is not retried
+		})
+		is.True(err != nil)
+		is.Equal(1, calls)
+	})
+	t.Run("savepoint", func(t *testing.T) {
+		t.Parallel()
+		is := is.New(t)
+		db, d := newFakeDB(t)
+		errInner := errors.New("inner")
+		err := Tx(context.Background(), db, TxOptions{}, func(tx *sql.Tx) error {
+			err := Tx(context.Background(), tx, TxOptions{}, func(tx *sql.Tx) error {
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			err = Tx(context.Background(), tx, TxOptions{}, func(tx *sql.Tx) error {
+				return errInner
+			})
+			is.Equal(errInner, err)
+			return nil
+		})
+		is.NoErr(err)
+		log := d.getLog()
+		is.Equal(6, len(log))
+		is.Equal("BEGIN", log[0])
+		var name1, name2 string
+		fmt.Sscanf(log[1], "SAVEPOINT %s", &name1)
+		fmt.Sscanf(log[3], "SAVEPOINT %s", &name2)
+		is.True(name1 != "" && name2 != "" && name1 != name2)
+		is.Equal("RELEASE SAVEPOINT "+name1, log[2])
+		is.Equal("ROLLBACK TO SAVEPOINT "+name2, log[4])
+		is.Equal("COMMIT", log[5])
+	})
+}
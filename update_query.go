@@ -24,8 +24,11 @@ type UpdateQuery struct {
 	WherePredicates qx.VariadicPredicate
 	// RETURNING
 	ReturningFields qx.Fields
+	AliasDuplicates bool
 	Mapper          func(Row)
 	Accumulator     func()
+	// Parameters
+	ParamValues interface{}
 	// Logging
 	Log qx.Logger
 }
@@ -71,10 +74,20 @@ func (q UpdateQuery) ToSQL() (string, []interface{}) {
 			}
 			if q.FromTable.GetAlias() != "" {
 				buf.WriteString("FROM " + fromQuery + " AS " + q.FromTable.GetAlias())
+				if columnAliases := qx.ColumnAliasSQL(q.FromTable); columnAliases != "" {
+					buf.WriteString(" " + columnAliases)
+				}
 			} else {
 				buf.WriteString("FROM " + fromQuery)
 			}
 			args = append(args, fromArgs...)
+			if sample, ok := q.FromTable.(qx.TableSample); ok {
+				sampleQuery, sampleArgs := sample.SampleSQL()
+				if sampleQuery != "" {
+					buf.WriteString(" " + sampleQuery)
+					args = append(args, sampleArgs...)
+				}
+			}
 		}
 	}
 	// JOIN
@@ -83,6 +96,9 @@ func (q UpdateQuery) ToSQL() (string, []interface{}) {
 	q.WherePredicates.Toplevel = true
 	q.WherePredicates.WriteSQL(buf, &args, "WHERE ", "", nil)
 	// RETURNING
+	if q.AliasDuplicates {
+		q.ReturningFields = qx.AliasDuplicateColumns(q.ReturningFields)
+	}
 	q.ReturningFields.WriteSQLWithAlias(buf, &args, "RETURNING ", "", nil)
 	query := buf.String()
 	if !q.Nested {
@@ -140,6 +156,16 @@ func (q UpdateQuery) From(tbl qx.Table) UpdateQuery {
 	return q
 }
 
+// FromValues sets the FROM table to a VALUES list with the alias and column
+// names, allowing many rows to be updated with different values in one query
+// i.e. 'UPDATE t SET a = v.a FROM (VALUES ...) AS v (id, a) WHERE t.id = v.id'.
+// The columns of the VALUES list can be referenced with
+// qx.ValuesTable.Field.
+func (q UpdateQuery) FromValues(valuesList qx.ValuesList, alias string, columns ...string) UpdateQuery {
+	q.FromTable = qx.NewValuesTable(valuesList, alias, columns...)
+	return q
+}
+
 func (q UpdateQuery) Join(tbl qx.Table, pred qx.Predicate, preds ...qx.Predicate) UpdateQuery {
 	preds = append([]qx.Predicate{pred}, preds...)
 	q.JoinGroups = append(q.JoinGroups, qx.JoinGroup{
@@ -198,6 +224,14 @@ func (q UpdateQuery) Returning(fields ...qx.Field) UpdateQuery {
 	return q
 }
 
+// AliasDuplicateColumns makes the UpdateQuery alias every table column in its
+// RETURNING list that shares its output name with another column as
+// 'tablealias_column'.
+func (q UpdateQuery) AliasDuplicateColumns() UpdateQuery {
+	q.AliasDuplicates = true
+	return q
+}
+
 func (q UpdateQuery) Returningx(mapper func(Row), accumulator func()) UpdateQuery {
 	q.Mapper = mapper
 	q.Accumulator = accumulator
@@ -209,7 +243,51 @@ func (q UpdateQuery) ReturningRowx(mapper func(Row)) UpdateQuery {
 	return q
 }
 
-func (q UpdateQuery) Exec(db qx.Queryer) (err error) {
+// Validate checks the UpdateQuery for mistakes that can be caught without
+// sending the query to the database. Exec calls Validate automatically.
+func (q UpdateQuery) Validate() error {
+	if err := validateArgs(q); err != nil {
+		return err
+	}
+	if q.Nested && !q.AliasDuplicates {
+		if err := qx.CheckDuplicateColumns(q.ReturningFields); err != nil {
+			return err
+		}
+	}
+	return validateNested(q.CTEs, append([]qx.Table{q.FromTable}, joinTables(q.JoinGroups)...)...)
+}
+
+// Bind sets the values of the qx.Parameters in the UpdateQuery, keyed by their
+// names. They are bound into the args when the query is executed.
+func (q UpdateQuery) Bind(values map[string]interface{}) UpdateQuery {
+	q.ParamValues = values
+	return q
+}
+
+// BindStruct is like Bind, but takes the values from the fields of a struct
+// (or pointer to a struct). Fields are matched by their `db` tag or, failing
+// that, their name.
+func (q UpdateQuery) BindStruct(v interface{}) UpdateQuery {
+	q.ParamValues = v
+	return q
+}
+
+// Exec executes the UpdateQuery. If a mapper was set via Returningx or
+// ReturningRowx, the RETURNING rows are scanned into it. Use ExecRowsAffected
+// to also get the number of rows affected.
+func (q UpdateQuery) Exec(db qx.Queryer) error {
+	_, err := q.ExecRowsAffected(db)
+	return err
+}
+
+// ExecRowsAffected is like Exec, but also returns the number of rows affected
+// by the UpdateQuery. If there is a mapper, it is the number of RETURNING rows,
+// which Postgres returns one of for every affected row. Otherwise, if db
+// implements qx.Execer (as *sql.DB and *sql.Tx do), the query is executed
+// with Exec instead of Query and the count comes from its sql.Result. If db
+// only implements qx.Queryer and there is no mapper, the count cannot be
+// determined and -1 is returned.
+func (q UpdateQuery) ExecRowsAffected(db qx.Queryer) (rowsAffected int64, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			switch v := r.(type) {
@@ -220,6 +298,9 @@ func (q UpdateQuery) Exec(db qx.Queryer) (err error) {
 			}
 		}
 	}()
+	if err = q.Validate(); err != nil {
+		return 0, err
+	}
 	r := &QyRow{QxRow: &qx.QxRow{}}
 	if q.Mapper != nil {
 		q.Mapper(r) // call the mapper once on the *Row to get all the selected that the user is interested in
@@ -227,31 +308,51 @@ func (q UpdateQuery) Exec(db qx.Queryer) (err error) {
 	q.ReturningFields = r.QxRow.Fields // then, transfer the selected collected by *Row to the InsertQuery
 	r.QxRow.Active = true              // mark Row as active i.e.
 	query, args := q.ToSQL()
+	if args, err = qx.BindParams(args, q.ParamValues); err != nil {
+		return 0, err
+	}
+	if len(q.ReturningFields) == 0 {
+		// if user didn't specify any fields to return, don't bother scanning
+		// anything and report the number of affected rows instead
+		if execer, ok := db.(qx.Execer); ok {
+			result, err := execer.Exec(query, args...)
+			if err != nil {
+				return 0, err
+			}
+			return result.RowsAffected()
+		}
+		rows, err := db.Query(query, args...)
+		if err != nil {
+			return 0, err
+		}
+		return -1, rows.Close()
+	}
 	rows, err := db.Query(query, args...)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer rows.Close()
-	var rowcount int
-	if len(q.ReturningFields) == 0 {
-		// if user didn't specify any fields to return, don't bother scanning anything and return early
-		return nil
-	}
 	for rows.Next() {
-		rowcount++
+		rowsAffected++
+		if rowsAffected > 1 && q.Accumulator == nil {
+			// only the first row is mapped, the rest are just counted
+			continue
+		}
 		err = rows.Scan(r.QxRow.Dest...)
 		if err != nil {
-			return err
+			return rowsAffected, err
 		}
 		r.QxRow.Index = 0 // index must always be reset back to 0 before mapper is called
 		q.Mapper(r)
-		if q.Accumulator == nil {
-			break
+		if q.Accumulator != nil {
+			q.Accumulator()
 		}
-		q.Accumulator()
 	}
-	if rowcount == 0 && q.Accumulator == nil {
-		return sql.ErrNoRows
+	if err = rows.Err(); err != nil {
+		return rowsAffected, err
+	}
+	if rowsAffected == 0 && q.Accumulator == nil {
+		return 0, sql.ErrNoRows
 	}
-	return rows.Err()
+	return rowsAffected, nil
 }
@@ -1 +1,177 @@
 package qy
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+	"github.com/bokwoon95/qx-postgres/tables"
+	"github.com/lib/pq"
+	"github.com/matryer/is"
+)
+
+func TestUpdateQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           UpdateQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "bulk update from VALUES"
+			u := tables.USERS().As("u")
+			v := qx.NewValuesTable(qx.ValuesList{
+				{Fieldf("?", 1), "aaa"},
+				{Fieldf("?", 2), "bbb"},
+			}, "v", "uid", "displayname")
+			q := Update(u).
+				Set(u.DISPLAYNAME.Set(v.Field("displayname"))).
+				From(v).
+				Where(u.UID.Eq(v.NumberField("uid")))
+			wantQuery := "UPDATE public.users AS u SET displayname = v.displayname" +
+				" FROM (VALUES ($1::INT, $2), ($3::INT, $4)) AS v (uid, displayname)" +
+				" WHERE u.uid = v.uid"
+			wantArgs := []interface{}{1, "aaa", 2, "bbb"}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "FromValues"
+			u := tables.USERS().As("u")
+			q := Update(u).
+				Set(u.EMAIL.Set(Fieldf("v.email"))).
+				FromValues(qx.ValuesList{{"aaa", "aaa@email.com"}}, "v", "displayname", "email").
+				Where(u.DISPLAYNAME.Eq(qx.ValuesTable{Alias: "v"}.StringField("displayname")))
+			wantQuery := "UPDATE public.users AS u SET email = v.email" +
+				" FROM (VALUES ($1, $2)) AS v (displayname, email)" +
+				" WHERE u.displayname = v.displayname"
+			wantArgs := []interface{}{"aaa", "aaa@email.com"}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "bulk update from unnest"
+			u := tables.USERS().As("u")
+			x := qx.Unnest([]int64{1, 2}, []string{"aaa", "bbb"}).As("x", "uid", "displayname")
+			q := Update(u).
+				Set(u.DISPLAYNAME.Set(x.StringField("displayname"))).
+				From(x).
+				Where(u.UID.Eq(x.NumberField("uid")))
+			wantQuery := "UPDATE public.users AS u SET displayname = x.displayname" +
+				" FROM unnest($1::BIGINT[], $2::TEXT[]) AS x (uid, displayname)" +
+				" WHERE u.uid = x.uid"
+			wantArgs := []interface{}{pq.Int64Array{1, 2}, pq.StringArray{"aaa", "bbb"}}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "multi-column SET from a subquery"
+			u, ur := tables.USERS().As("u"), tables.USER_ROLES().As("ur")
+			q := Update(u).
+				Set(qx.Fields{u.DISPLAYNAME, u.EMAIL}.Set(
+					Select(ur.ROLE, ur.COHORT).From(ur).Where(ur.UID.Eq(u.UID), ur.URID.EqInt(5)),
+				)).
+				Where(u.UID.EqInt(1))
+			wantQuery := "UPDATE public.users AS u SET (displayname, email) =" +
+				" (SELECT ur.role, ur.cohort FROM public.user_roles AS ur WHERE ur.uid = u.uid AND ur.urid = $1)" +
+				" WHERE u.uid = $2"
+			wantArgs := []interface{}{5, 1}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "multi-column SET from a ROW"
+			u := tables.USERS().As("u")
+			q := Update(u).
+				Set(qx.Fields{u.DISPLAYNAME, u.EMAIL, u.PASSWORD}.Set(qx.Row("aaa", Fieldf("LOWER(?)", "AAA@email.com"), qx.Default))).
+				Where(u.UID.EqInt(1))
+			wantQuery := "UPDATE public.users AS u SET (displayname, email, password) =" +
+				" ROW($1, LOWER($2::TEXT), DEFAULT) WHERE u.uid = $3"
+			wantArgs := []interface{}{"aaa", "AAA@email.com", 1}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "SET column to DEFAULT"
+			u := tables.USERS().As("u")
+			q := Update(u).Set(u.PASSWORD.Set(qx.Default)).Where(u.UID.EqInt(1))
+			wantQuery := "UPDATE public.users AS u SET password = DEFAULT WHERE u.uid = $1"
+			wantArgs := []interface{}{1}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+// fakeExecer records the query passed to Exec and reports a fixed number of
+// affected rows. Its Query method always fails, so that tests can tell which
+// path was taken.
+type fakeExecer struct {
+	query        string
+	args         []interface{}
+	rowsAffected int64
+}
+
+func (db *fakeExecer) Exec(query string, args ...interface{}) (sql.Result, error) {
+	db.query = query
+	db.args = args
+	return driver.RowsAffected(db.rowsAffected), nil
+}
+
+func (db *fakeExecer) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("Query should not be called")
+}
+
+func TestUpdateQuery_RowsAffected(t *testing.T) {
+	is := is.New(t)
+	u := tables.USERS().As("u")
+	db := &fakeExecer{rowsAffected: 3}
+	rowsAffected, err := Update(u).Set(u.DISPLAYNAME.SetString("x")).Where(u.UID.GtInt(5)).ExecRowsAffected(db)
+	is.NoErr(err)
+	is.Equal(int64(3), rowsAffected)
+	is.Equal("UPDATE public.users AS u SET displayname = $1 WHERE u.uid > $2", db.query)
+
+	db = &fakeExecer{rowsAffected: 2}
+	rowsAffected, err = DeleteFrom(u).Where(u.UID.GtInt(5)).ExecRowsAffected(db)
+	is.NoErr(err)
+	is.Equal(int64(2), rowsAffected)
+
+	db = &fakeExecer{rowsAffected: 1}
+	rowsAffected, err = InsertInto(u).InsertRow(u.DISPLAYNAME.SetString("x")).ExecRowsAffected(db)
+	is.NoErr(err)
+	is.Equal(int64(1), rowsAffected)
+
+	// a mapper forces the query to go through Query
+	err = DeleteFrom(u).Where(u.UID.GtInt(5)).ReturningRowx(func(row Row) { row.Int(u.UID) }).Exec(db)
+	is.True(err != nil)
+}
+
+func TestUpdateQuery_Bind(t *testing.T) {
+	is := is.New(t)
+	u := tables.USERS().As("u")
+	q := Update(u).
+		Set(u.DISPLAYNAME.Set(qx.Param("name"))).
+		Where(u.UID.Eq(qx.NumberParam("uid")))
+	wantQuery := "UPDATE public.users AS u SET displayname = $1 WHERE u.uid = $2"
+	db := &fakeExecer{}
+	err := q.Bind(map[string]interface{}{"name": "bob", "uid": 1}).Exec(db)
+	is.NoErr(err)
+	is.Equal(wantQuery, db.query)
+	is.Equal([]interface{}{"bob", 1}, db.args)
+	err = q.BindStruct(struct {
+		Name string `db:"name"`
+		UID  int    `db:"uid"`
+	}{"alice", 2}).Exec(db)
+	is.NoErr(err)
+	is.Equal(wantQuery, db.query)
+	is.Equal([]interface{}{"alice", 2}, db.args)
+	err = q.Bind(map[string]interface{}{"name": "bob"}).Exec(db)
+	is.True(err != nil) // uid is not bound
+}
@@ -0,0 +1,72 @@
+package qy
+
+import (
+	"fmt"
+
+	"github.com/bokwoon95/qx-postgres/qx"
+)
+
+// validator is implemented by queries that can check themselves for mistakes
+// before being sent to the database.
+type validator interface {
+	Validate() error
+}
+
+// validateNested validates the queries nested inside the CTEs and tables of a
+// query. Each query is validated in its nested form, so that checks which only
+// matter when a query is used by another query (such as duplicate output
+// columns) are applied.
+func validateNested(ctes qx.CTEs, tables ...qx.Table) error {
+	for _, cte := range ctes {
+		if err := validateQuery(cte.Query); err != nil {
+			return fmt.Errorf("CTE %s: %w", cte.Name, err)
+		}
+	}
+	for _, table := range tables {
+		query, ok := table.(qx.Query)
+		if !ok {
+			continue
+		}
+		if err := validateQuery(query); err != nil {
+			return fmt.Errorf("subquery %s: %w", query.GetAlias(), err)
+		}
+	}
+	return nil
+}
+
+// validateQuery validates the nested form of the query, if it can be
+// validated. The queries of a set operation are validated individually.
+func validateQuery(query qx.Query) error {
+	if query == nil {
+		return nil
+	}
+	if variadic, ok := query.(qx.VariadicQuery); ok {
+		for _, q := range variadic.Queries {
+			if err := validateQuery(q); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if v, ok := query.NestThis().(validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// joinTables returns the tables of the JoinGroups.
+func joinTables(joinGroups qx.JoinGroups) []qx.Table {
+	tables := make([]qx.Table, len(joinGroups))
+	for i := range joinGroups {
+		tables[i] = joinGroups[i].Table
+	}
+	return tables
+}
+
+// validateArgs returns the first qx.ColumnError in the args of the query,
+// i.e. a reference to an output column that does not exist or has the wrong
+// type.
+func validateArgs(query qx.Query) error {
+	_, args := query.NestThis().ToSQL()
+	return qx.CheckArgs(args)
+}